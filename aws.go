@@ -0,0 +1,108 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// the units of the rate expressions
+	awsRateUnits = map[string]time.Duration{
+		"minute":  time.Minute,
+		"minutes": time.Minute,
+		"hour":    time.Hour,
+		"hours":   time.Hour,
+		"day":     24 * time.Hour,
+		"days":    24 * time.Hour,
+	}
+)
+
+// parses an AWS EventBridge schedule expression, either "cron(...)" or "rate(...)", and returns the schedule it represents
+//
+// cron expressions have the six fields of EventBridge: minutes, hours, day of month, month, day of week (1-7 or SUN-SAT, 1 is sunday) and year, with "?", "L", "W" and "#", and exactly one of the day of month and day of week fields must be "?"; they return a *Cron. Rate expressions ("rate(5 minutes)", "rate(1 hour)", "rate(7 days)") return an IntervalSchedule anchored at the Unix epoch, so they are aligned to the clock in UTC
+//
+// it returns an error when the syntax of expression is wrong
+func ParseAWS(expr string, tz *time.Location) (Schedule, error) {
+	trimmed := strings.TrimSpace(expr)
+
+	kind, rest, ok := strings.Cut(trimmed, "(")
+	if !ok || !strings.HasSuffix(rest, ")") || (kind != "cron" && kind != "rate") {
+		return nil, &ParseError{
+			Token:      trimmed,
+			Reason:     fmt.Sprintf("'%s' is not a cron() or rate() expression", trimmed),
+			Suggestion: "use cron(minutes hours day-of-month month day-of-week year) or rate(value unit)",
+		}
+	}
+
+	inner := rest[:len(rest)-1]
+
+	if kind == "rate" {
+		return parseAWSRate(inner)
+	}
+
+	if n := len(strings.Fields(inner)); n != 6 {
+		return nil, &ParseError{
+			Token:      inner,
+			Offset:     strings.Index(expr, inner),
+			Reason:     fmt.Sprintf("expected 6 fields, got %d", n),
+			Suggestion: "use minutes, hours, day of month, month, day of week and year",
+		}
+	}
+
+	// the EventBridge fields are the Quartz ones without the seconds
+	c, err := ParseQuartz("0 "+inner, tz)
+	if err != nil {
+		var parseErr *ParseError
+		if errors.As(err, &parseErr) {
+			parseErr.Offset += strings.Index(expr, inner) - len("0 ")
+		}
+
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// returns the same result as ParseAWS, but it panics when the syntax of expression is wrong
+func MustParseAWS(expr string, tz *time.Location) Schedule {
+	s, err := ParseAWS(expr, tz)
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// returns the schedule of the value and unit of a rate expression
+func parseAWSRate(rate string) (Schedule, error) {
+	fields := strings.Fields(rate)
+	if len(fields) != 2 {
+		return nil, &ParseError{
+			Token:      rate,
+			Reason:     fmt.Sprintf("'%s' is not a value and a unit", rate),
+			Suggestion: "use rate(value unit); e.g., rate(5 minutes)",
+		}
+	}
+
+	value, err := strconv.Atoi(fields[0])
+	if err != nil || value < 1 {
+		return nil, &ParseError{
+			Token:  fields[0],
+			Reason: fmt.Sprintf("'%s' is not a positive number", fields[0]),
+		}
+	}
+
+	unit, ok := awsRateUnits[strings.ToLower(fields[1])]
+	if !ok {
+		return nil, &ParseError{
+			Token:      fields[1],
+			Reason:     fmt.Sprintf("'%s' is not a unit", fields[1]),
+			Suggestion: "use minutes, hours or days",
+		}
+	}
+
+	return Every(time.Duration(value)*unit, time.Unix(0, 0))
+}
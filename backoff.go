@@ -0,0 +1,75 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+type (
+	// a schedule that skips occurrences of Schedule after consecutive failures, doubling the effective interval on each failure up to a max factor
+	BackoffSchedule struct {
+		schedule  Schedule
+		maxFactor int
+
+		mu       sync.Mutex
+		failures int
+	}
+)
+
+// returns a schedule with the occurrences of s while the runs succeed
+//
+// after n consecutive failures reported with Report, it returns only one of every 2^n occurrences of s (at most one of every maxFactor), so failing jobs ease the pressure on broken dependencies. A success restores the occurrences of s
+func NewBackoffSchedule(s Schedule, maxFactor int) *BackoffSchedule {
+	return &BackoffSchedule{
+		schedule:  s,
+		maxFactor: max(maxFactor, 1),
+	}
+}
+
+// records the result of a run: a nil error resets the backoff, any other error stretches the interval
+func (b *BackoffSchedule) Report(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+}
+
+// returns the number of consecutive failures reported since the last success
+func (b *BackoffSchedule) Failures() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.failures
+}
+
+// returns the occurrence of the schedule after t that follows the skipped ones
+func (b *BackoffSchedule) Next(t time.Time) (time.Time, error) {
+	for i := b.factor(); i > 0; i-- {
+		next, err := b.schedule.Next(t)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		t = next
+	}
+
+	return t, nil
+}
+
+// returns the number of occurrences of the schedule per occurrence of the backoff
+func (b *BackoffSchedule) factor() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	factor := 1
+	for i := 0; i < b.failures && factor < b.maxFactor; i++ {
+		factor = factor * 2
+	}
+
+	return min(factor, b.maxFactor)
+}
@@ -0,0 +1,99 @@
+package cron
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+type (
+	// a schedule that returns at most a number of occurrences of Schedule per rolling window, skipping the extra ones
+	BudgetSchedule struct {
+		schedule Schedule
+		max      int
+		window   time.Duration
+		onSkip   func(time.Time)
+
+		mu sync.Mutex
+		// the occurrences reported by Fired within the last window, sorted
+		runs []time.Time
+	}
+)
+
+// returns a schedule with the occurrences of s, except the ones that would exceed n runs within any window
+//
+// the budget counts the runs reported by Fired, so Next can be called any number of times, e.g. to preview the schedule. onSkip, if not nil, is called by Fired with each occurrence skipped since the previous run
+func NewBudgetSchedule(s Schedule, n int, window time.Duration, onSkip func(time.Time)) *BudgetSchedule {
+	return &BudgetSchedule{
+		schedule: s,
+		max:      max(n, 1),
+		window:   window,
+		onSkip:   onSkip,
+	}
+}
+
+// returns the first occurrence of the schedule after t that fits the budget left by the runs reported by Fired
+func (b *BudgetSchedule) Next(t time.Time) (time.Time, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for {
+		next, err := b.schedule.Next(t)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if b.fits(next) {
+			return next, nil
+		}
+
+		t = next
+	}
+}
+
+// records a run of the occurrence t, returned by Next, so it counts against the budget, and calls onSkip with the occurrences skipped since the previous run
+func (b *BudgetSchedule) Fired(t time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if slices.ContainsFunc(b.runs, t.Equal) {
+		return
+	}
+
+	// only the occurrences within a window from the previous run can exceed the budget; the ones that fit it were not run for other reasons
+	if b.onSkip != nil && len(b.runs) > 0 {
+		last := b.runs[len(b.runs)-1]
+		end := last.Add(b.window)
+
+		for next, err := b.schedule.Next(last); err == nil && next.Before(t) && next.Before(end); next, err = b.schedule.Next(next) {
+			if !b.fits(next) {
+				b.onSkip(next)
+			}
+		}
+	}
+
+	// forget the runs that are out of the window ending at t
+	start := t.Add(-b.window)
+	b.runs = slices.DeleteFunc(b.runs, func(r time.Time) bool { return !r.After(start) })
+
+	i, _ := slices.BinarySearchFunc(b.runs, t, func(r, t time.Time) int { return r.Compare(t) })
+	b.runs = slices.Insert(b.runs, i, t)
+}
+
+// reports whether the occurrence t fits the budget, counting the runs within the window ending at t; a run of t itself fits
+func (b *BudgetSchedule) fits(t time.Time) bool {
+	start := t.Add(-b.window)
+
+	count := 0
+	for _, r := range b.runs {
+		if r.Equal(t) {
+			return true
+		}
+
+		if r.After(start) && r.Before(t) {
+			count++
+		}
+	}
+
+	return count < b.max
+}
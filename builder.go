@@ -0,0 +1,266 @@
+package cron
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+type (
+	MinuteOfHour uint8
+	HourOfDay    uint8
+	DayOfMonth   uint8
+	MonthOfYear  uint8
+	DayOfWeek    uint8
+
+	// builds a Cron from typed field values instead of an expression
+	Builder struct {
+		minute bitset64
+		hour   bitset32
+		dom    bitset32
+		month  bitset16
+		dow    bitset8
+		tz     *time.Location
+		err    error
+	}
+)
+
+var (
+	ErrOutOfRange = errors.New("value out of range")
+)
+
+// returns the minute value, or ErrOutOfRange if it is not within 0-59
+func Minute(v int) (MinuteOfHour, error) {
+	if !boundMinute.contains(v) {
+		return 0, ErrOutOfRange
+	}
+
+	return MinuteOfHour(v), nil
+}
+
+// returns the hour value, or ErrOutOfRange if it is not within 0-23
+func Hour(v int) (HourOfDay, error) {
+	if !boundHour.contains(v) {
+		return 0, ErrOutOfRange
+	}
+
+	return HourOfDay(v), nil
+}
+
+// returns the day of month value, or ErrOutOfRange if it is not within 1-31
+func Day(v int) (DayOfMonth, error) {
+	if !boundDOM.contains(v) {
+		return 0, ErrOutOfRange
+	}
+
+	return DayOfMonth(v), nil
+}
+
+// returns the month value for the given month, or ErrOutOfRange if it is not within time.January-time.December
+func Month(m time.Month) (MonthOfYear, error) {
+	if !boundMonth.contains(int(m)) {
+		return 0, ErrOutOfRange
+	}
+
+	return MonthOfYear(m), nil
+}
+
+// returns the day of week value for the given weekday, or ErrOutOfRange if it is not within time.Sunday-time.Saturday
+func Weekday(d time.Weekday) (DayOfWeek, error) {
+	if !boundDOW.contains(int(d)) {
+		return 0, ErrOutOfRange
+	}
+
+	return DayOfWeek(d), nil
+}
+
+// returns the value as a time.Month
+func (m MonthOfYear) Month() time.Month {
+	return time.Month(m)
+}
+
+// returns the value as a time.Weekday
+func (d DayOfWeek) Weekday() time.Weekday {
+	return time.Weekday(d)
+}
+
+// returns a new builder for a schedule in the given timezone
+//
+// fields without values match all the allowed values, like an asterisk in the expression
+func NewBuilder(tz *time.Location) *Builder {
+	return &Builder{tz: tz}
+}
+
+// adds the given minutes to the schedule
+//
+// invalid minutes make Build return ErrOutOfRange
+func (b *Builder) Minutes(v ...MinuteOfHour) *Builder {
+	for _, m := range v {
+		minute, err := Minute(int(m))
+		if err != nil {
+			b.err = err
+			continue
+		}
+
+		b.minute = b.minute | 1<<minute
+	}
+
+	return b
+}
+
+// adds the given hours to the schedule
+//
+// invalid hours make Build return ErrOutOfRange
+func (b *Builder) Hours(v ...HourOfDay) *Builder {
+	for _, h := range v {
+		hour, err := Hour(int(h))
+		if err != nil {
+			b.err = err
+			continue
+		}
+
+		b.hour = b.hour | 1<<hour
+	}
+
+	return b
+}
+
+// adds the given days of month to the schedule
+//
+// invalid days of month make Build return ErrOutOfRange
+func (b *Builder) Days(v ...DayOfMonth) *Builder {
+	for _, d := range v {
+		day, err := Day(int(d))
+		if err != nil {
+			b.err = err
+			continue
+		}
+
+		b.dom = b.dom | 1<<day
+	}
+
+	return b
+}
+
+// adds the given months to the schedule
+//
+// invalid months make Build return ErrOutOfRange
+func (b *Builder) Months(v ...time.Month) *Builder {
+	for _, m := range v {
+		month, err := Month(m)
+		if err != nil {
+			b.err = err
+			continue
+		}
+
+		b.month = b.month | 1<<month
+	}
+
+	return b
+}
+
+// adds the given days of week to the schedule
+//
+// invalid weekdays make Build return ErrOutOfRange
+func (b *Builder) Weekdays(v ...time.Weekday) *Builder {
+	for _, d := range v {
+		dow, err := Weekday(d)
+		if err != nil {
+			b.err = err
+			continue
+		}
+
+		b.dow = b.dow | 1<<dow
+	}
+
+	return b
+}
+
+// returns the schedule with the values added to the builder, or an error if any of the values was invalid
+func (b *Builder) Build() (*Cron, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	c := &Cron{
+		second: 1,
+		minute: b.minute,
+		hour:   b.hour,
+		dom:    b.dom,
+		month:  b.month,
+		dow:    b.dow,
+		tz:     b.tz,
+	}
+
+	if c.minute == 0 {
+		c.minute = buildBitset[bitset64](boundMinute.min, boundMinute.max, 1)
+	}
+
+	if c.hour == 0 {
+		c.hour = buildBitset[bitset32](boundHour.min, boundHour.max, 1)
+	}
+
+	if c.dom == 0 {
+		c.dom = buildBitset[bitset32](boundDOM.min, boundDOM.max, 1)
+	}
+
+	if c.month == 0 {
+		c.month = buildBitset[bitset16](boundMonth.min, boundMonth.max, 1)
+	}
+
+	if c.dow == 0 {
+		c.dow = buildBitset[bitset8](boundDOW.min, boundDOW.max, 1)
+	}
+
+	c.fast = c.fastPath()
+
+	return c, nil
+}
+
+// returns the minutes matched by the schedule
+func (s *Cron) Minutes() []MinuteOfHour {
+	return bitsetValues[bitset64, MinuteOfHour](s.minute, boundMinute)
+}
+
+// returns the hours matched by the schedule
+func (s *Cron) Hours() []HourOfDay {
+	return bitsetValues[bitset32, HourOfDay](s.hour, boundHour)
+}
+
+// returns the days of month matched by the schedule
+func (s *Cron) Days() []DayOfMonth {
+	return bitsetValues[bitset32, DayOfMonth](s.dom, boundDOM)
+}
+
+// returns the months matched by the schedule
+func (s *Cron) Months() []time.Month {
+	return bitsetValues[bitset16, time.Month](s.month, boundMonth)
+}
+
+// returns the days of week matched by the schedule
+func (s *Cron) Weekdays() []time.Weekday {
+	return bitsetValues[bitset8, time.Weekday](s.dow, boundDOW)
+}
+
+// returns the years matched by the schedule, or nil if it matches every year
+func (s *Cron) Years() []int {
+	return slices.Clone(s.year)
+}
+
+// returns the timezone of the schedule
+func (s *Cron) Location() *time.Location {
+	return s.tz
+}
+
+// returns the values of the bits set to 1 within the bounds, in ascending order
+func bitsetValues[T bitset8 | bitset16 | bitset32 | bitset64, V ~uint8 | ~int](b T, bounds fieldBounds) []V {
+	var values []V
+
+	for i := bounds.min; i <= bounds.max; i++ {
+		if b&(1<<i) != 0 {
+			values = append(values, V(i))
+		}
+	}
+
+	return values
+}
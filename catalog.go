@@ -0,0 +1,402 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// the phrases of the descriptions of Describe in a language
+	//
+	// the phrases with verbs are fmt formats, whose operands are given in the comments with an English example. The phrases of the days are functions, since their grammar depends on the number (e.g. "1er" in French) or on the weekday (e.g. the gender in Portuguese)
+	Catalog struct {
+		// the names of the weekdays from sunday, alone and in plural ("Mondays"), and of the months from january
+		Weekdays       [7]string
+		PluralWeekdays [7]string
+		Months         [12]string
+
+		// the word before the last item of a list ("and")
+		And string
+		// the first and last names of a range ("%s through %s")
+		Through string
+
+		// the times of day ("at %s")
+		AtTimes string
+		// the steps of the time fields ("every %d seconds"), and every value of them ("every second")
+		EverySeconds, EveryMinutes, EveryHours string
+		EverySecond, EveryMinute, EveryHour    string
+		// a single second or minute ("at %d seconds past the minute"), or a list of them ("at seconds %s past the minute")
+		AtSecond, AtSeconds string
+		AtMinute, AtMinutes string
+		// the first and last times of a range of hours ("between %s and %s"), or a list of hours ("during the hours %s")
+		BetweenHours, DuringHours string
+
+		// the days of every month ("on %s of every month"), or the days and the months ("on %s of %s")
+		OnDays, OnDaysIn string
+		// the weekdays that must match the days of month too ("%s only")
+		WeekdaysOnly string
+		// the weekdays ("only on %s"), months ("only in %s") and years ("in %s") of the schedules running on any day of month
+		OnWeekdays, InMonths, InYears string
+
+		// a day of month ("the 1st"), and a range of them ("the 1st through the 7th")
+		Day      func(d int) string
+		DayRange func(first, last int) string
+		// the last day of the month ("the last day"), and n days before it ("the 2nd to last day" for 1)
+		LastDay       string
+		DayBeforeLast func(n int) string
+		// the weekday nearest to a day ("the weekday nearest the 15th"), and the last weekday of the month ("the last weekday")
+		NearestWeekday func(d int) string
+		LastWeekday    string
+		// the nth occurrence of a weekday in the month ("the 2nd Friday"), and the last one ("the last Friday")
+		NthWeekday    func(n int, w time.Weekday) string
+		LastOfWeekday func(w time.Weekday) string
+	}
+)
+
+var (
+	// the catalogs of the languages of Describe, by language; add a catalog to describe the schedules in another language
+	Catalogs = map[string]*Catalog{
+		"en": catalogEN,
+		"es": catalogES,
+		"de": catalogDE,
+		"fr": catalogFR,
+		"pt": catalogPT,
+	}
+
+	// the weekdays of the catalogs whose phrases of the nth weekdays name them
+	weekdaysES = [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"}
+	weekdaysDE = [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"}
+	weekdaysFR = [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"}
+	weekdaysPT = [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"}
+
+	catalogEN = &Catalog{
+		Weekdays:       [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		PluralWeekdays: [7]string{"Sundays", "Mondays", "Tuesdays", "Wednesdays", "Thursdays", "Fridays", "Saturdays"},
+		Months:         [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		And:            "and",
+		Through:        "%s through %s",
+		AtTimes:        "at %s",
+		EverySeconds:   "every %d seconds",
+		EveryMinutes:   "every %d minutes",
+		EveryHours:     "every %d hours",
+		EverySecond:    "every second",
+		EveryMinute:    "every minute",
+		EveryHour:      "every hour",
+		AtSecond:       "at %d seconds past the minute",
+		AtSeconds:      "at seconds %s past the minute",
+		AtMinute:       "at %d minutes past the hour",
+		AtMinutes:      "at minutes %s past the hour",
+		BetweenHours:   "between %s and %s",
+		DuringHours:    "during the hours %s",
+		OnDays:         "on %s of every month",
+		OnDaysIn:       "on %s of %s",
+		WeekdaysOnly:   "%s only",
+		OnWeekdays:     "only on %s",
+		InMonths:       "only in %s",
+		InYears:        "in %s",
+		Day: func(d int) string {
+			return "the " + ordinal(d)
+		},
+		DayRange: func(first, last int) string {
+			return "the " + ordinal(first) + " through the " + ordinal(last)
+		},
+		LastDay: "the last day",
+		DayBeforeLast: func(n int) string {
+			return "the " + ordinal(n+1) + " to last day"
+		},
+		NearestWeekday: func(d int) string {
+			return "the weekday nearest the " + ordinal(d)
+		},
+		LastWeekday: "the last weekday",
+		NthWeekday: func(n int, w time.Weekday) string {
+			return "the " + ordinal(n) + " " + w.String()
+		},
+		LastOfWeekday: func(w time.Weekday) string {
+			return "the last " + w.String()
+		},
+	}
+
+	catalogES = &Catalog{
+		Weekdays:       weekdaysES,
+		PluralWeekdays: [7]string{"domingos", "lunes", "martes", "miércoles", "jueves", "viernes", "sábados"},
+		Months:         [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		And:            "y",
+		Through:        "%s a %s",
+		AtTimes:        "a las %s",
+		EverySeconds:   "cada %d segundos",
+		EveryMinutes:   "cada %d minutos",
+		EveryHours:     "cada %d horas",
+		EverySecond:    "cada segundo",
+		EveryMinute:    "cada minuto",
+		EveryHour:      "cada hora",
+		AtSecond:       "en el segundo %d de cada minuto",
+		AtSeconds:      "en los segundos %s de cada minuto",
+		AtMinute:       "en el minuto %d de cada hora",
+		AtMinutes:      "en los minutos %s de cada hora",
+		BetweenHours:   "entre las %s y las %s",
+		DuringHours:    "durante las horas %s",
+		OnDays:         "%s de cada mes",
+		OnDaysIn:       "%s de %s",
+		WeekdaysOnly:   "solo los %s",
+		OnWeekdays:     "solo los %s",
+		InMonths:       "solo en %s",
+		InYears:        "en %s",
+		Day: func(d int) string {
+			return fmt.Sprintf("el día %d", d)
+		},
+		DayRange: func(first, last int) string {
+			return fmt.Sprintf("del día %d al %d", first, last)
+		},
+		LastDay: "el último día",
+		DayBeforeLast: func(n int) string {
+			if n == 1 {
+				return "el penúltimo día"
+			}
+
+			return fmt.Sprintf("%d días antes del último día", n)
+		},
+		NearestWeekday: func(d int) string {
+			return fmt.Sprintf("el día laborable más cercano al %d", d)
+		},
+		LastWeekday: "el último día laborable",
+		NthWeekday: func(n int, w time.Weekday) string {
+			return "el " + []string{"", "primer", "segundo", "tercer", "cuarto", "quinto"}[n] + " " + weekdaysES[w]
+		},
+		LastOfWeekday: func(w time.Weekday) string {
+			return "el último " + weekdaysES[w]
+		},
+	}
+
+	catalogDE = &Catalog{
+		Weekdays:       weekdaysDE,
+		PluralWeekdays: [7]string{"sonntags", "montags", "dienstags", "mittwochs", "donnerstags", "freitags", "samstags"},
+		Months:         [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		And:            "und",
+		Through:        "%s bis %s",
+		AtTimes:        "um %s",
+		EverySeconds:   "alle %d Sekunden",
+		EveryMinutes:   "alle %d Minuten",
+		EveryHours:     "alle %d Stunden",
+		EverySecond:    "jede Sekunde",
+		EveryMinute:    "jede Minute",
+		EveryHour:      "jede Stunde",
+		AtSecond:       "in Sekunde %d jeder Minute",
+		AtSeconds:      "in den Sekunden %s jeder Minute",
+		AtMinute:       "in Minute %d jeder Stunde",
+		AtMinutes:      "in den Minuten %s jeder Stunde",
+		BetweenHours:   "zwischen %s und %s",
+		DuringHours:    "in den Stunden %s",
+		OnDays:         "%s jedes Monats",
+		OnDaysIn:       "%s im %s",
+		WeekdaysOnly:   "nur %s",
+		OnWeekdays:     "nur %s",
+		InMonths:       "nur im %s",
+		InYears:        "im Jahr %s",
+		Day: func(d int) string {
+			return fmt.Sprintf("am %d.", d)
+		},
+		DayRange: func(first, last int) string {
+			return fmt.Sprintf("vom %d. bis %d.", first, last)
+		},
+		LastDay: "am letzten Tag",
+		DayBeforeLast: func(n int) string {
+			if n == 1 {
+				return "am vorletzten Tag"
+			}
+
+			return fmt.Sprintf("%d Tage vor dem letzten Tag", n)
+		},
+		NearestWeekday: func(d int) string {
+			return fmt.Sprintf("am nächsten Wochentag zum %d.", d)
+		},
+		LastWeekday: "am letzten Wochentag",
+		NthWeekday: func(n int, w time.Weekday) string {
+			return fmt.Sprintf("am %d. %s", n, weekdaysDE[w])
+		},
+		LastOfWeekday: func(w time.Weekday) string {
+			return "am letzten " + weekdaysDE[w]
+		},
+	}
+
+	catalogFR = &Catalog{
+		Weekdays:       weekdaysFR,
+		PluralWeekdays: [7]string{"dimanches", "lundis", "mardis", "mercredis", "jeudis", "vendredis", "samedis"},
+		Months:         [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		And:            "et",
+		Through:        "%s à %s",
+		AtTimes:        "à %s",
+		EverySeconds:   "toutes les %d secondes",
+		EveryMinutes:   "toutes les %d minutes",
+		EveryHours:     "toutes les %d heures",
+		EverySecond:    "chaque seconde",
+		EveryMinute:    "chaque minute",
+		EveryHour:      "chaque heure",
+		AtSecond:       "à la seconde %d de chaque minute",
+		AtSeconds:      "aux secondes %s de chaque minute",
+		AtMinute:       "à la minute %d de chaque heure",
+		AtMinutes:      "aux minutes %s de chaque heure",
+		BetweenHours:   "entre %s et %s",
+		DuringHours:    "pendant les heures %s",
+		OnDays:         "%s de chaque mois",
+		OnDaysIn:       "%s de %s",
+		WeekdaysOnly:   "uniquement les %s",
+		OnWeekdays:     "uniquement les %s",
+		InMonths:       "uniquement en %s",
+		InYears:        "en %s",
+		Day: func(d int) string {
+			return "le " + frenchDay(d)
+		},
+		DayRange: func(first, last int) string {
+			return "du " + frenchDay(first) + " au " + frenchDay(last)
+		},
+		LastDay: "le dernier jour",
+		DayBeforeLast: func(n int) string {
+			if n == 1 {
+				return "l'avant-dernier jour"
+			}
+
+			return fmt.Sprintf("%d jours avant le dernier jour", n)
+		},
+		NearestWeekday: func(d int) string {
+			return "le jour ouvré le plus proche du " + frenchDay(d)
+		},
+		LastWeekday: "le dernier jour ouvré",
+		NthWeekday: func(n int, w time.Weekday) string {
+			return "le " + []string{"", "premier", "deuxième", "troisième", "quatrième", "cinquième"}[n] + " " + weekdaysFR[w]
+		},
+		LastOfWeekday: func(w time.Weekday) string {
+			return "le dernier " + weekdaysFR[w]
+		},
+	}
+
+	catalogPT = &Catalog{
+		Weekdays:       weekdaysPT,
+		PluralWeekdays: [7]string{"domingos", "segundas-feiras", "terças-feiras", "quartas-feiras", "quintas-feiras", "sextas-feiras", "sábados"},
+		Months:         [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		And:            "e",
+		Through:        "%s a %s",
+		AtTimes:        "às %s",
+		EverySeconds:   "a cada %d segundos",
+		EveryMinutes:   "a cada %d minutos",
+		EveryHours:     "a cada %d horas",
+		EverySecond:    "a cada segundo",
+		EveryMinute:    "a cada minuto",
+		EveryHour:      "a cada hora",
+		AtSecond:       "no segundo %d de cada minuto",
+		AtSeconds:      "nos segundos %s de cada minuto",
+		AtMinute:       "no minuto %d de cada hora",
+		AtMinutes:      "nos minutos %s de cada hora",
+		BetweenHours:   "entre %s e %s",
+		DuringHours:    "durante as horas %s",
+		OnDays:         "%s de cada mês",
+		OnDaysIn:       "%s de %s",
+		WeekdaysOnly:   "apenas %s",
+		OnWeekdays:     "apenas %s",
+		InMonths:       "apenas em %s",
+		InYears:        "em %s",
+		Day: func(d int) string {
+			return fmt.Sprintf("no dia %d", d)
+		},
+		DayRange: func(first, last int) string {
+			return fmt.Sprintf("do dia %d ao %d", first, last)
+		},
+		LastDay: "no último dia",
+		DayBeforeLast: func(n int) string {
+			if n == 1 {
+				return "no penúltimo dia"
+			}
+
+			return fmt.Sprintf("%d dias antes do último dia", n)
+		},
+		NearestWeekday: func(d int) string {
+			return fmt.Sprintf("no dia útil mais próximo do dia %d", d)
+		},
+		LastWeekday: "no último dia útil",
+		// saturday and sunday are masculine, the other weekdays feminine
+		NthWeekday: func(n int, w time.Weekday) string {
+			if w == time.Saturday || w == time.Sunday {
+				return fmt.Sprintf("no %dº %s", n, weekdaysPT[w])
+			}
+
+			return fmt.Sprintf("na %dª %s", n, weekdaysPT[w])
+		},
+		LastOfWeekday: func(w time.Weekday) string {
+			if w == time.Saturday || w == time.Sunday {
+				return "no último " + weekdaysPT[w]
+			}
+
+			return "na última " + weekdaysPT[w]
+		},
+	}
+)
+
+// returns the catalog of the language, of its base language ("pt" for "pt-BR"), or the English one
+func catalogFor(lang string) *Catalog {
+	lang = strings.ToLower(lang)
+
+	if c, ok := Catalogs[lang]; ok {
+		return c
+	}
+
+	base, _, _ := strings.Cut(strings.ReplaceAll(lang, "_", "-"), "-")
+	if c, ok := Catalogs[base]; ok {
+		return c
+	}
+
+	return catalogEN
+}
+
+// returns the sorted values as a list, using the range of the catalog for three or more consecutive values
+func (c *Catalog) list(values []int, name func(int) string) string {
+	var items []string
+
+	for _, run := range valueRuns(values) {
+		if run[1]-run[0] >= 2 {
+			items = append(items, fmt.Sprintf(c.Through, name(run[0]), name(run[1])))
+			continue
+		}
+
+		for v := run[0]; v <= run[1]; v++ {
+			items = append(items, name(v))
+		}
+	}
+
+	return c.join(items)
+}
+
+// returns the items joined with commas and the "and" of the catalog before the last one
+func (c *Catalog) join(items []string) string {
+	if len(items) < 2 {
+		return strings.Join(items, "")
+	}
+
+	return strings.Join(items[:len(items)-1], ", ") + " " + c.And + " " + items[len(items)-1]
+}
+
+// returns the English ordinal of n, e.g. "1st", "12th" or "23rd"
+func ordinal(n int) string {
+	suffix := "th"
+
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+
+	return strconv.Itoa(n) + suffix
+}
+
+// returns a day of month in French, where the first one is "1er"
+func frenchDay(d int) string {
+	if d == 1 {
+		return "1er"
+	}
+
+	return strconv.Itoa(d)
+}
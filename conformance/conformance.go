@@ -0,0 +1,155 @@
+// Package conformance provides table-driven scenarios of the cron package, so that other implementations and custom schedules (e.g. wrappers of a *cron.Cron) can be checked to behave like the core
+//
+// the scenarios cover the changes of DST in both directions and in timezones with unusual changes, leap years, the boundaries of the lengths of the months and the quirks of each dialect
+package conformance
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"cron"
+)
+
+type (
+	// the syntax of the expression of a scenario
+	Dialect int
+
+	Scenario struct {
+		Name    string
+		Dialect Dialect
+		Expr    string
+		// the IANA name of the timezone of the schedule
+		Zone string
+		// the time, in RFC 3339, from which the occurrences are searched
+		From string
+		// the next occurrences after From, in RFC 3339 with the offset of the timezone of the schedule
+		Want []string
+		// whether there is no occurrence after the last one of Want within the year limit
+		End bool
+	}
+
+	// returns the schedule of an expression of a dialect in a timezone
+	ParseFunc func(expr string, tz *time.Location) (cron.Schedule, error)
+)
+
+const (
+	// five fields and an optional year, parsed by cron.Parse
+	Standard Dialect = iota
+	// seconds, five fields and an optional year, with "?", parsed by cron.ParseQuartz
+	Quartz
+)
+
+var (
+	Scenarios = []Scenario{
+		// DST changes
+		{"new york hourly at spring forward", Standard, "0 * * * *", "America/New_York", "2024-03-10T00:30:00-05:00", []string{"2024-03-10T01:00:00-05:00", "2024-03-10T03:00:00-04:00", "2024-03-10T04:00:00-04:00"}, false},
+		{"new york skipped time runs shifted", Standard, "30 2 * * *", "America/New_York", "2024-03-09T12:00:00-05:00", []string{"2024-03-10T03:30:00-04:00", "2024-03-11T02:30:00-04:00"}, false},
+		{"new york skipped times keep their order", Standard, "*/30 2 * * *", "America/New_York", "2024-03-10T01:00:00-05:00", []string{"2024-03-10T03:00:00-04:00", "2024-03-10T03:30:00-04:00", "2024-03-11T02:00:00-04:00"}, false},
+		{"new york hourly at fall back", Standard, "0 * * * *", "America/New_York", "2024-11-03T00:30:00-04:00", []string{"2024-11-03T01:00:00-04:00", "2024-11-03T01:00:00-05:00", "2024-11-03T02:00:00-05:00"}, false},
+		{"new york repeated time runs twice", Standard, "30 1 * * *", "America/New_York", "2024-11-02T12:00:00-04:00", []string{"2024-11-03T01:30:00-04:00", "2024-11-03T01:30:00-05:00", "2024-11-04T01:30:00-05:00"}, false},
+		{"new york day of 23 hours", Standard, "30 23 * * *", "America/New_York", "2024-03-09T23:35:00-05:00", []string{"2024-03-10T23:30:00-04:00"}, false},
+		{"new york day of 25 hours", Standard, "30 23 * * *", "America/New_York", "2024-11-02T23:35:00-04:00", []string{"2024-11-03T23:30:00-05:00"}, false},
+		{"london skipped hour", Standard, "0 1 * * *", "Europe/London", "2024-03-30T12:00:00Z", []string{"2024-03-31T02:00:00+01:00", "2024-04-01T01:00:00+01:00"}, false},
+		{"sydney skipped time", Standard, "30 2 * * *", "Australia/Sydney", "2024-10-05T12:00:00+10:00", []string{"2024-10-06T03:30:00+11:00", "2024-10-07T02:30:00+11:00"}, false},
+		{"sydney repeated time", Standard, "30 2 * * *", "Australia/Sydney", "2024-04-06T12:00:00+11:00", []string{"2024-04-07T02:30:00+11:00", "2024-04-07T02:30:00+10:00", "2024-04-08T02:30:00+10:00"}, false},
+		{"lord howe change of half an hour forward", Standard, "0 * * * *", "Australia/Lord_Howe", "2024-10-06T01:00:00+10:30", []string{"2024-10-06T02:30:00+11:00", "2024-10-06T03:00:00+11:00", "2024-10-06T04:00:00+11:00"}, false},
+		{"lord howe change of half an hour back", Standard, "*/30 * * * *", "Australia/Lord_Howe", "2024-04-07T01:00:00+11:00", []string{"2024-04-07T01:30:00+11:00", "2024-04-07T01:30:00+10:30", "2024-04-07T02:00:00+10:30"}, false},
+		{"santiago skipped midnight", Standard, "0 0 * * *", "America/Santiago", "2024-09-07T12:00:00-04:00", []string{"2024-09-08T01:00:00-03:00", "2024-09-09T00:00:00-03:00"}, false},
+		{"santiago day starting at 01:00", Standard, "30 23 * * *", "America/Santiago", "2024-09-06T23:45:00-04:00", []string{"2024-09-07T23:30:00-04:00", "2024-09-08T23:30:00-03:00"}, false},
+		{"apia skipped day", Standard, "0 12 * * *", "Pacific/Apia", "2011-12-29T12:00:00-10:00", []string{"2011-12-31T12:00:00+14:00", "2012-01-01T12:00:00+14:00"}, false},
+
+		// leap years
+		{"29 of february", Standard, "0 0 29 2 *", "UTC", "2023-01-01T00:00:00Z", []string{"2024-02-29T00:00:00Z", "2028-02-29T00:00:00Z"}, false},
+		{"last day of february", Standard, "0 0 L 2 *", "UTC", "2023-01-01T00:00:00Z", []string{"2023-02-28T00:00:00Z", "2024-02-29T00:00:00Z", "2025-02-28T00:00:00Z"}, false},
+		{"2100 is not a leap year", Standard, "0 0 L 2 *", "UTC", "2099-03-01T00:00:00Z", []string{"2100-02-28T00:00:00Z"}, false},
+
+		// lengths of the months
+		{"31st day", Standard, "0 0 31 * *", "UTC", "2024-01-31T00:00:00Z", []string{"2024-03-31T00:00:00Z", "2024-05-31T00:00:00Z", "2024-07-31T00:00:00Z"}, false},
+		{"30th day", Standard, "0 0 30 * *", "UTC", "2024-01-30T00:00:00Z", []string{"2024-03-30T00:00:00Z", "2024-04-30T00:00:00Z"}, false},
+		{"last day", Standard, "0 0 L * *", "UTC", "2024-01-15T00:00:00Z", []string{"2024-01-31T00:00:00Z", "2024-02-29T00:00:00Z", "2024-03-31T00:00:00Z"}, false},
+		{"day before the last day", Standard, "0 0 L-1 * *", "UTC", "2024-01-15T00:00:00Z", []string{"2024-01-30T00:00:00Z", "2024-02-28T00:00:00Z", "2024-03-30T00:00:00Z"}, false},
+		{"fifth monday", Standard, "0 0 * * 1#5", "UTC", "2024-01-01T00:00:00Z", []string{"2024-01-29T00:00:00Z", "2024-04-29T00:00:00Z", "2024-07-29T00:00:00Z"}, false},
+		{"last minute of the year", Standard, "59 23 31 12 *", "UTC", "2024-06-01T00:00:00Z", []string{"2024-12-31T23:59:00Z", "2025-12-31T23:59:00Z"}, false},
+
+		// dialect quirks
+		{"7 is sunday", Standard, "0 0 * * 7", "UTC", "2024-01-01T00:00:00Z", []string{"2024-01-07T00:00:00Z", "2024-01-14T00:00:00Z"}, false},
+		{"day of month and day of week both match", Standard, "0 0 13 * 5", "UTC", "2024-01-01T00:00:00Z", []string{"2024-09-13T00:00:00Z", "2024-12-13T00:00:00Z", "2025-06-13T00:00:00Z"}, false},
+		{"nearest weekday", Standard, "0 17 15W * *", "UTC", "2024-06-01T00:00:00Z", []string{"2024-06-14T17:00:00Z", "2024-07-15T17:00:00Z", "2024-08-15T17:00:00Z"}, false},
+		{"nearest weekday within the month", Standard, "0 0 1W * *", "UTC", "2024-05-15T00:00:00Z", []string{"2024-06-03T00:00:00Z", "2024-07-01T00:00:00Z"}, false},
+		{"quartz third friday", Quartz, "0 0 12 ? * 6#3", "UTC", "2024-01-01T00:00:00Z", []string{"2024-01-19T12:00:00Z", "2024-02-16T12:00:00Z", "2024-03-15T12:00:00Z"}, false},
+		{"quartz 1 is sunday", Quartz, "0 0 12 ? * 1", "UTC", "2024-01-01T00:00:00Z", []string{"2024-01-07T12:00:00Z", "2024-01-14T12:00:00Z"}, false},
+		{"quartz last weekday", Quartz, "0 15 10 LW * ?", "UTC", "2024-06-01T00:00:00Z", []string{"2024-06-28T10:15:00Z", "2024-07-31T10:15:00Z", "2024-08-30T10:15:00Z"}, false},
+		{"quartz seconds", Quartz, "*/20 * * * * ?", "UTC", "2024-01-01T10:00:05Z", []string{"2024-01-01T10:00:20Z", "2024-01-01T10:00:40Z", "2024-01-01T10:01:00Z"}, false},
+		{"quartz single year", Quartz, "0 0 0 1 1 ? 2030", "UTC", "2024-01-01T00:00:00Z", []string{"2030-01-01T00:00:00Z"}, true},
+	}
+)
+
+// runs the scenarios of the dialect as subtests, with the schedules returned by parse
+//
+// the occurrences are chained from From with Next, and with Prev backwards when the schedule has it; the scenarios whose timezone isn't available are skipped
+func Run(t *testing.T, dialect Dialect, parse ParseFunc) {
+	for _, sc := range Scenarios {
+		if sc.Dialect != dialect {
+			continue
+		}
+
+		t.Run(sc.Name, func(t *testing.T) {
+			sc.check(t, parse)
+		})
+	}
+}
+
+// checks the occurrences of the schedule of the scenario
+func (sc Scenario) check(t *testing.T, parse ParseFunc) {
+	tz, err := time.LoadLocation(sc.Zone)
+	if err != nil {
+		t.Skipf("timezone %s: %v", sc.Zone, err)
+	}
+
+	s, err := parse(sc.Expr, tz)
+	if err != nil {
+		t.Fatalf("%q: %v", sc.Expr, err)
+	}
+
+	from, err := time.Parse(time.RFC3339, sc.From)
+	if err != nil {
+		t.Fatalf("from %q: %v", sc.From, err)
+	}
+
+	prev := from
+	for _, want := range sc.Want {
+		next, err := s.Next(prev)
+		if err != nil {
+			t.Fatalf("%q after %s: expected %s, got %v", sc.Expr, prev.In(tz).Format(time.RFC3339), want, err)
+		}
+
+		if got := next.In(tz).Format(time.RFC3339); got != want {
+			t.Fatalf("%q after %s: expected %s, got %s", sc.Expr, prev.In(tz).Format(time.RFC3339), want, got)
+		}
+
+		prev = next
+	}
+
+	if sc.End {
+		if next, err := s.Next(prev); !errors.Is(err, cron.ErrMaxYearLimit) {
+			t.Fatalf("%q after %s: expected %v, got %v, %v", sc.Expr, prev.In(tz).Format(time.RFC3339), cron.ErrMaxYearLimit, next, err)
+		}
+	}
+
+	p, ok := s.(interface {
+		Prev(t time.Time) (time.Time, error)
+	})
+	if !ok {
+		return
+	}
+
+	for i := len(sc.Want) - 1; i > 0; i-- {
+		next, _ := time.Parse(time.RFC3339, sc.Want[i])
+
+		got, err := p.Prev(next)
+		if err != nil || got.In(tz).Format(time.RFC3339) != sc.Want[i-1] {
+			t.Fatalf("%q before %s: expected %s, got %v, %v", sc.Expr, sc.Want[i], sc.Want[i-1], got, err)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package conformance
+
+import (
+	"testing"
+	"time"
+
+	"cron"
+)
+
+func TestCron(t *testing.T) {
+	Run(t, Standard, func(expr string, tz *time.Location) (cron.Schedule, error) {
+		return cron.Parse(expr, tz)
+	})
+}
+
+func TestQuartz(t *testing.T) {
+	Run(t, Quartz, func(expr string, tz *time.Location) (cron.Schedule, error) {
+		return cron.ParseQuartz(expr, tz)
+	})
+}
+
+func TestMonotonicSchedule(t *testing.T) {
+	Run(t, Standard, func(expr string, tz *time.Location) (cron.Schedule, error) {
+		c, err := cron.Parse(expr, tz)
+		if err != nil {
+			return nil, err
+		}
+
+		return cron.NewMonotonicSchedule(c, time.Time{}, cron.AfterLast), nil
+	})
+}
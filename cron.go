@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math/bits"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -17,15 +18,33 @@ type (
 
 	fieldBounds struct {
 		min, max int
+		name     string
+		// the names accepted as values, indexed from min
+		aliases []string
 	}
 
 	Cron struct {
+		second bitset64
 		minute bitset64
 		hour   bitset32
 		dom    bitset32
-		month  bitset16
-		dow    bitset8
-		tz     *time.Location
+		// days counted back from the last day of the month; bit 0 is "L", bit 3 is "L-3"
+		domLast bitset32
+		// days matching the weekday nearest to them ("15W"); bit 0 is the last day of the month ("LW")
+		domWeekday bitset32
+		month      bitset16
+		dow        bitset8
+		// weekdays matching only their last occurrence in the month ("5L")
+		dowLast bitset8
+		// occurrences in the month of each weekday; bit 2 of dowNth[5] is "5#2"
+		dowNth [7]bitset8
+		// sorted matching years, nil when the year field is omitted or "*"
+		year []int
+		tz   *time.Location
+		// whether the timezone was given by a "CRON_TZ=" or "TZ=" prefix of the expression, which String keeps
+		tzPrefix bool
+		// the specialized Next of the common expressions
+		fast fastPath
 	}
 )
 
@@ -34,16 +53,48 @@ const (
 )
 
 var (
-	boundMinute = fieldBounds{0, 59}
-	boundHour   = fieldBounds{0, 23}
-	boundDOM    = fieldBounds{1, 31}
-	boundMonth  = fieldBounds{1, 12}
-	boundDOW    = fieldBounds{0, 6}
+	boundSecond = fieldBounds{0, 59, "second", nil}
+	boundMinute = fieldBounds{0, 59, "minute", nil}
+	boundHour   = fieldBounds{0, 23, "hour", nil}
+	boundDOM    = fieldBounds{1, 31, "day of month", nil}
+	boundMonth  = fieldBounds{1, 12, "month", []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}}
+	boundDOW    = fieldBounds{0, 6, "day of week", []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}}
+	boundYear   = fieldBounds{1970, 2099, "year", nil}
+
+	// the day of week field also accepts 7 for sunday, which is folded onto 0
+	boundDOWInput = fieldBounds{boundDOW.min, 7, boundDOW.name, boundDOW.aliases}
+
+	// the descriptor shortcuts accepted instead of the five fields
+	macros = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
 
 	ErrInvalidExpression = errors.New("invalid cron expression")
 	ErrMaxYearLimit      = errors.New("there is no date matching the expression within the year limit")
 )
 
+// reports whether v is within the bounds, inclusive
+func (b fieldBounds) contains(v int) bool {
+	return v >= b.min && v <= b.max
+}
+
+// returns the value of a number or a name (case insensitive) of the field
+func (b fieldBounds) value(s string) (int, error) {
+	for i, alias := range b.aliases {
+		if strings.EqualFold(s, alias) {
+			return b.min + i, nil
+		}
+	}
+
+	return strconv.Atoi(s)
+}
+
 // returns the same result as Parse, but it panics when the syntax of expression is wrong
 func MustParse(expr string, tz *time.Location) *Cron {
 	c, err := Parse(expr, tz)
@@ -56,50 +107,85 @@ func MustParse(expr string, tz *time.Location) *Cron {
 
 // parses the expression and returns a new schedule representing the given spec
 //
-// it returns an error when the syntax of expression is wrong
+// the expression has five fields and an optional sixth year field, or is one of the macros (@yearly, @annually, @monthly, @weekly, @daily, @midnight, @hourly)
+//
+// it may start with a "CRON_TZ=" or "TZ=" prefix (e.g. "CRON_TZ=Europe/Madrid 30 6 * * *") whose timezone overrides tz
+//
+// it returns an error when the syntax of expression is wrong, or ErrExpressionTooComplex when it exceeds MaxExpressionLength, MaxListItems or MaxParts
 func Parse(expr string, tz *time.Location) (*Cron, error) {
-	fields := strings.Fields(strings.TrimSpace(expr))
-	if len(fields) != 5 {
-		return nil, ErrInvalidExpression
+	return NewParser(WithLocation(tz)).Parse(expr)
+}
+
+// parses the fields of an expression, from the seconds to the optional year, into a schedule
+//
+// the numbering of the day of week field is given by its bounds: the min is sunday
+func parseFields(fields []string, dowBounds fieldBounds, tz *time.Location) (*Cron, error) {
+	if err := checkParts(fields); err != nil {
+		return nil, err
+	}
+
+	second, err := parseField[bitset64](fields[0], boundSecond)
+	if err != nil {
+		return nil, err
 	}
 
-	minute, err := parseField[bitset64](fields[0], boundMinute)
+	minute, err := parseField[bitset64](fields[1], boundMinute)
 	if err != nil {
 		return nil, err
 	}
 
-	hour, err := parseField[bitset32](fields[1], boundHour)
+	hour, err := parseField[bitset32](fields[2], boundHour)
 	if err != nil {
 		return nil, err
 	}
 
-	dom, err := parseField[bitset32](fields[2], boundDOM)
+	dom, domLast, domWeekday, err := parseDOMField(fields[3])
 	if err != nil {
 		return nil, err
 	}
 
-	month, err := parseField[bitset16](fields[3], boundMonth)
+	month, err := parseField[bitset16](fields[4], boundMonth)
 	if err != nil {
 		return nil, err
 	}
 
-	dow, err := parseField[bitset8](fields[4], boundDOW)
+	dow, dowLast, dowNth, err := parseDOWField(fields[5], dowBounds)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Cron{
-		minute: minute,
-		hour:   hour,
-		dom:    dom,
-		month:  month,
-		dow:    dow,
-		tz:     tz,
-	}, nil
+	var year []int
+	if len(fields) == 7 {
+		year, err = parseYearField(fields[6])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c := &Cron{
+		second:     second,
+		minute:     minute,
+		hour:       hour,
+		dom:        dom,
+		domLast:    domLast,
+		domWeekday: domWeekday,
+		month:      month,
+		dow:        dow,
+		dowLast:    dowLast,
+		dowNth:     dowNth,
+		year:       year,
+		tz:         tz,
+	}
+
+	c.fast = c.fastPath()
+
+	return c, nil
 }
 
 // returns an int with the bits set to 1 depending on the frecuency setted for the field, or an error if the field expression is invalid
 //
+// the field is a list of items separated by ",", and the step of an item applies only to its own range (e.g. "1,15,30-50/5" is 1, 15, 30, 35, ..., 50); the result is the union of the items
+//
 // for dow = 7 => 1111111b = 127d
 func parseField[T bitset8 | bitset16 | bitset32 | bitset64](field string, bounds fieldBounds) (T, error) {
 	var result T = 0
@@ -120,17 +206,212 @@ func parseField[T bitset8 | bitset16 | bitset32 | bitset64](field string, bounds
 	return result, nil
 }
 
+// returns the days of month bitset, the bitset of days counted back from the last day of the month and the bitset of days matching their nearest weekday, or an error if the field expression is invalid
+//
+// besides the syntax of the other fields, the day of month field accepts "?" (any day), "L" (the last day of the month), "L-n" (n days before the last day of the month, e.g. "L-3"), a day followed by "W" (the weekday nearest to that day, e.g. "15W") and "LW" (the last weekday of the month)
+func parseDOMField(field string) (bitset32, bitset32, bitset32, error) {
+	var dom, domLast, domWeekday bitset32
+
+	// "?" means no specific value, which is the same as "*" because both fields must match
+	if field == "?" {
+		field = "*"
+	}
+
+	fieldParts := strings.Split(field, ",")
+	for i := 0; i < len(fieldParts); i++ {
+		fieldPart := fieldParts[i]
+
+		if strings.EqualFold(fieldPart, "L") {
+			domLast = domLast | 1
+			continue
+		}
+
+		// the last weekday of the month is the weekday nearest to its last day
+		if strings.EqualFold(fieldPart, "LW") {
+			domWeekday = domWeekday | 1
+			continue
+		}
+
+		// "L-n" is n days before the last day of the month
+		if len(fieldPart) > 2 && strings.EqualFold(fieldPart[:2], "L-") {
+			n, err := strconv.Atoi(fieldPart[2:])
+			if err != nil || n < 0 || n >= boundDOM.max {
+				return 0, 0, 0, boundDOM.syntaxError(fieldPart, fmt.Sprintf("'%s' is not a number of days from 0 to %d", fieldPart[2:], boundDOM.max-1), "")
+			}
+
+			domLast = domLast | 1<<n
+			continue
+		}
+
+		if len(fieldPart) > 1 && strings.HasSuffix(strings.ToUpper(fieldPart), "W") {
+			day, err := strconv.Atoi(fieldPart[:len(fieldPart)-1])
+			if err != nil {
+				return 0, 0, 0, boundDOM.notANumberError(fieldPart, fieldPart[:len(fieldPart)-1])
+			}
+
+			if !boundDOM.contains(day) {
+				return 0, 0, 0, boundDOM.outOfRangeError(fieldPart, day)
+			}
+
+			domWeekday = domWeekday | 1<<day
+			continue
+		}
+
+		partialResult, err := parseFieldPart[bitset32](fieldPart, boundDOM)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+
+		dom = dom | partialResult
+	}
+
+	return dom, domLast, domWeekday, nil
+}
+
+// returns the days of week bitset, the bitset of weekdays matching their last occurrence in the month and the occurrences in the month of each weekday, or an error if the field expression is invalid
+//
+// besides the syntax of the other fields, the day of week field accepts "?" (any weekday), a weekday followed by "L" (its last occurrence in the month, e.g. "5L" or "FRIL"), a weekday followed by "#" and a number from 1 to 5 (its nth occurrence in the month, e.g. "FRI#2"), and 7 for sunday
+//
+// the bounds give the numbering of the weekdays: the min is sunday, and a max of 7 days after it is sunday again
+func parseDOWField(field string, bounds fieldBounds) (bitset8, bitset8, [7]bitset8, error) {
+	var dow, dowLast bitset8
+	var dowNth [7]bitset8
+
+	// "?" means no specific value, which is the same as "*" because both fields must match
+	if field == "?" {
+		field = "*"
+	}
+
+	fieldParts := strings.Split(field, ",")
+	for i := 0; i < len(fieldParts); i++ {
+		fieldPart := fieldParts[i]
+
+		if weekdayPart, nthPart, ok := strings.Cut(fieldPart, "#"); ok {
+			weekday, err := parseWeekday(fieldPart, weekdayPart, bounds)
+			if err != nil {
+				return 0, 0, dowNth, err
+			}
+
+			nth, err := strconv.Atoi(nthPart)
+			if err != nil || nth < 1 || nth > 5 {
+				return 0, 0, dowNth, bounds.syntaxError(fieldPart, fmt.Sprintf("'%s' is not an occurrence from 1 to 5", nthPart), "")
+			}
+
+			dowNth[weekday] = dowNth[weekday] | 1<<nth
+			continue
+		}
+
+		if len(fieldPart) > 1 && strings.HasSuffix(strings.ToUpper(fieldPart), "L") {
+			weekday, err := parseWeekday(fieldPart, fieldPart[:len(fieldPart)-1], bounds)
+			if err != nil {
+				return 0, 0, dowNth, err
+			}
+
+			dowLast = dowLast | 1<<weekday
+			continue
+		}
+
+		partBounds := bounds
+		if openRange(fieldPart) {
+			partBounds = weekBounds(bounds)
+		}
+
+		partialResult, err := parseFieldPart[bitset8](fieldPart, partBounds)
+		if err != nil {
+			return 0, 0, dowNth, err
+		}
+
+		// shift the values so that sunday is bit 0
+		dow = dow | partialResult>>bounds.min
+	}
+
+	// 7 days after sunday is sunday again
+	if dow&(1<<7) != 0 {
+		dow = dow&^(1<<7) | 1
+	}
+
+	return dow, dowLast, dowNth, nil
+}
+
+// reports whether the end of the range of the field part is implicit: "*" (with or without a step) and "n/step", which end at the max of the field
+func openRange(fieldPart string) bool {
+	rangePart, _, hasStep := strings.Cut(fieldPart, "/")
+
+	return rangePart == "*" || hasStep && !strings.Contains(rangePart, "-")
+}
+
+// returns the bounds of a single week of the day of week field with the given bounds, so the implicit ends of the ranges stop at saturday instead of sunday again (e.g. "MON/2" is monday, wednesday and friday)
+func weekBounds(bounds fieldBounds) fieldBounds {
+	return fieldBounds{bounds.min, bounds.min + 6, bounds.name, bounds.aliases}
+}
+
+// returns the weekday (0-6) of a single number or name of the day of week field with the given bounds
+func parseWeekday(fieldPart, v string, bounds fieldBounds) (int, error) {
+	weekday, err := bounds.value(v)
+	if err != nil {
+		return 0, bounds.notANumberError(fieldPart, v)
+	}
+
+	if !bounds.contains(weekday) {
+		return 0, bounds.outOfRangeError(fieldPart, weekday)
+	}
+
+	return (weekday - bounds.min) % 7, nil
+}
+
 // returns an int with the bits set to 1 depending on the frecuency setted for the field part, or an error if the field expression is invalid
 //
 // fPart = number | number "-" number [ "/" number ]
 func parseFieldPart[T bitset8 | bitset16 | bitset32 | bitset64](fPart string, fBounds fieldBounds) (T, error) {
+	begin, end, step, err := parseRange(fPart, fBounds)
+	if err != nil {
+		return 0, err
+	}
+
+	return buildBitset[T](begin, end, step), nil
+}
+
+// returns the sorted years matching the year field, or nil if it matches all the years
+//
+// the years can't be stored in a bitset, but the field has the same syntax as the others
+func parseYearField(field string) ([]int, error) {
+	if field == "*" {
+		return nil, nil
+	}
+
+	matches := make([]bool, boundYear.max-boundYear.min+1)
+
+	fieldParts := strings.Split(field, ",")
+	for i := 0; i < len(fieldParts); i++ {
+		begin, end, step, err := parseRange(fieldParts[i], boundYear)
+		if err != nil {
+			return nil, err
+		}
+
+		for y := begin; y <= end; y += step {
+			matches[y-boundYear.min] = true
+		}
+	}
+
+	var years []int
+	for i, match := range matches {
+		if match {
+			years = append(years, i+boundYear.min)
+		}
+	}
+
+	return years, nil
+}
+
+// returns the begining, end and step of the field part, or an error if the field expression is invalid
+func parseRange(fPart string, fBounds fieldBounds) (int, int, int, error) {
 	// replace "*" into "min-max".
 	newexpr := strings.Replace(fPart, "*", fmt.Sprintf("%d-%d", fBounds.min, fBounds.max), 1)
 
 	// split by /
 	rangeAndStep := strings.Split(newexpr, "/")
 	if len(rangeAndStep) > 2 {
-		return 0, ErrInvalidExpression
+		return 0, 0, 0, fBounds.syntaxError(fPart, fmt.Sprintf("'%s' has more than one step", fPart), "")
 	}
 
 	hasStep := len(rangeAndStep) == 2
@@ -139,17 +420,17 @@ func parseFieldPart[T bitset8 | bitset16 | bitset32 | bitset64](fPart string, fB
 	// split by -
 	lowAndHigh := strings.Split(rangeAndStep[0], "-")
 	if len(lowAndHigh) > 2 {
-		return 0, ErrInvalidExpression
+		return 0, 0, 0, fBounds.syntaxError(fPart, fmt.Sprintf("'%s' is not a valid range", fPart), "")
 	}
 
 	// get the begining of the range
-	begin, err := strconv.Atoi(lowAndHigh[0])
+	begin, err := fBounds.value(lowAndHigh[0])
 	if err != nil {
-		return 0, ErrInvalidExpression
+		return 0, 0, 0, fBounds.notANumberError(fPart, lowAndHigh[0])
 	}
 
-	if begin > fBounds.max || begin < fBounds.min {
-		return 0, ErrInvalidExpression
+	if !fBounds.contains(begin) {
+		return 0, 0, 0, fBounds.outOfRangeError(fPart, begin)
 	}
 
 	var end int
@@ -161,36 +442,46 @@ func parseFieldPart[T bitset8 | bitset16 | bitset32 | bitset64](fPart string, fB
 	} else if len(lowAndHigh) == 1 && !hasStep {
 		end = begin
 	} else if len(lowAndHigh) == 2 {
-		end, err = strconv.Atoi(lowAndHigh[1])
+		end, err = fBounds.value(lowAndHigh[1])
 		if err != nil {
-			return 0, ErrInvalidExpression
+			return 0, 0, 0, fBounds.notANumberError(fPart, lowAndHigh[1])
 		}
 	}
 
-	if end > fBounds.max || end < fBounds.min {
-		return 0, ErrInvalidExpression
+	if !fBounds.contains(end) {
+		return 0, 0, 0, fBounds.outOfRangeError(fPart, end)
 	}
 
 	if end < begin {
-		return 0, ErrInvalidExpression
+		return 0, 0, 0, fBounds.syntaxError(fPart, fmt.Sprintf("range '%s' is reversed", rangeAndStep[0]), fmt.Sprintf("did you mean %d-%d?", end, begin))
 	}
 
 	/// parse the step
 	step := 1
 	if hasStep {
 		step, err = strconv.Atoi(rangeAndStep[1])
-		if err != nil {
-			return 0, ErrInvalidExpression
+		if err != nil || step < 1 {
+			return 0, 0, 0, fBounds.syntaxError(fPart, fmt.Sprintf("step '%s' is not a positive number", rangeAndStep[1]), "")
 		}
 	}
 
-	return buildBitset[T](begin, end, step), nil
+	return begin, end, step, nil
 }
 
 // creates the bit set
+//
+// the values are limited to the width of the bit set, and a step below 1 sets no bits, so the loop is bounded whatever the input
 func buildBitset[T bitset8 | bitset16 | bitset32 | bitset64](min, max, step int) T {
 	var b T
 
+	if step < 1 || min < 0 {
+		return b
+	}
+
+	if width := bits.Len64(uint64(^T(0))); max >= width {
+		max = width - 1
+	}
+
 	for i := min; i <= max; i += step {
 		b = b | (1 << i)
 	}
@@ -199,26 +490,71 @@ func buildBitset[T bitset8 | bitset16 | bitset32 | bitset64](min, max, step int)
 }
 
 // returns the next time that matches the expression in the timezone of the input
+//
+// the times skipped when the clocks go forward run shifted by the length of the change (e.g. 02:30 runs at 03:30 when the clocks jump from 02:00 to 03:00), and the times repeated when the clocks go back run twice
 func (s *Cron) Next(t time.Time) (time.Time, error) {
+	if next, ok := s.nextFast(t); ok {
+		return next, nil
+	}
+
+	next, err := s.nextWallClock(t)
+
+	// the wall clock times skipped by a DST change before the next occurrence (or within the year limit)
+	limit := next
+	if err != nil {
+		limit = t.AddDate(yearLimit, 0, 0)
+	}
+
+	if skipped, ok := s.nextSkipped(t, limit); ok {
+		return skipped, nil
+	}
+
+	return next, err
+}
+
+// returns the next time after t whose wall clock time matches the expression, in the timezone of the schedule
+func (s *Cron) nextWallClock(t time.Time) (time.Time, error) {
 	t = t.In(s.tz)
 
 	// calculates the max possible year for the loop
 	maxYear := t.Year() + yearLimit
 
-	// set the sec and nsec to 0 and add a minute (the closest match)
-	t = t.Truncate(time.Minute).Add(1 * time.Minute)
+	if s.second == 1 {
+		// set the sec and nsec to 0 and add a minute (the closest match)
+		t = t.Truncate(time.Minute).Add(1 * time.Minute)
+	} else {
+		// set the nsec to 0 and add a second (the closest match)
+		t = t.Truncate(time.Second).Add(1 * time.Second)
+	}
 
 	// get the len of the bitsets in bits
 	monthBitsLen := bits.Len(uint(s.month))
-	domBitsLen := bits.Len(uint(s.dom))
 	hourBitsLen := bits.Len(uint(s.hour))
 	minuteBitsLen := bits.Len(uint(s.minute))
+	secondBitsLen := bits.Len(uint(s.second))
 
 loop:
 	if t.Year() > maxYear {
 		return time.Time{}, ErrMaxYearLimit
 	}
 
+	// find the first year matching the expression
+	if s.year != nil && !slices.Contains(s.year, t.Year()) {
+		// get the next year in the list
+		i, _ := slices.BinarySearch(s.year, t.Year())
+
+		// if there is no next year, the expression will never match again
+		if i >= len(s.year) {
+			return time.Time{}, ErrMaxYearLimit
+		}
+
+		// the year limit is counted from the first matching year
+		maxYear = s.year[i] + yearLimit
+
+		// if the year value has to be increased, reset the less significant time parts to 0
+		t = startOfDay(s.year[i], time.January, 1, t.Location())
+	}
+
 	// find the first month matching the expression
 	if 1<<int(t.Month())&s.month == 0 {
 		// get the next month in the bitset
@@ -231,56 +567,29 @@ loop:
 
 		// if there is no next month, reset to the next year
 		if i >= monthBitsLen {
-			t = time.Date(t.Year(), 1, 1, 0, 0, 0, 0, t.Location()).AddDate(1, 0, 0)
+			t = startOfDay(t.Year()+1, time.January, 1, t.Location())
 			goto loop
 		}
 
 		// if the month value has to be increased, reset the less significant time parts to 0
-		t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
-
-		// calculate the difference between the date month and the next month in the expression
-		diff := i - int(t.Month())
-
-		// add the difference to the date
-		t = t.AddDate(0, diff, 0)
+		t = startOfDay(t.Year(), time.Month(i), 1, t.Location())
 	}
 
-	month := t.Month()
 	// find the first day matching the expression (day of week and day of month)
-	if 1<<t.Day()&s.dom == 0 || 1<<int(t.Weekday())&s.dow == 0 {
-		// get the next day in the bitset
-		var i int
-		for i = t.Day() + 1; i < domBitsLen; i++ {
-			if s.dom&(1<<i) != 0 {
-				break
-			}
-		}
+	year, month, day := t.Date()
+	days := s.days(year, month)
+	if 1<<day&days == 0 {
+		// get the next day in the bitset, discarding the days until the current one
+		next := days >> (day + 1) << (day + 1)
 
 		// if there is no next day, reset to the next month
-		if i >= domBitsLen {
-			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()).AddDate(0, 1, 0)
+		if next == 0 {
+			t = startOfDay(year, month+1, 1, t.Location())
 			goto loop
 		}
 
 		// if the day value has to be increased, reset the less significant time parts to 0
-		t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
-
-		// calculate the difference between the date day and the next day in the expression
-		diff := i - int(t.Day())
-
-		// add the difference to the date
-		t = t.AddDate(0, 0, diff)
-
-		// if the month changed, run the loop again to ensure the maxYear and month conditions
-		if t.Month() != month {
-			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
-			goto loop
-		}
-
-		// if the weekday is not matching, run the loop again to ensure the maxYear, month and dom conditions
-		if 1<<int(t.Weekday())&s.dow == 0 {
-			goto loop
-		}
+		t = startOfDay(year, month, bits.TrailingZeros32(uint32(next)), t.Location())
 	}
 
 	// find the first day matching the expression
@@ -295,18 +604,30 @@ loop:
 
 		// if there is no next hour, reset to the next day
 		if i >= hourBitsLen {
-			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).AddDate(0, 0, 1)
+			t = startOfDay(t.Year(), t.Month(), t.Day()+1, t.Location())
 			goto loop
 		}
 
-		// if the hour value has to be increased, reset the less significant time parts to 0
-		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
-
 		// calculate the difference between the date hour and the next hour in the expression
 		diff := i - int(t.Hour())
 
+		// if the hour value has to be increased, reset the less significant time parts to 0, keeping the offset of t (time.Date picks the first of the repeated times when the clocks go back)
+		t = t.Add(-time.Duration(t.Minute())*time.Minute - time.Duration(t.Second())*time.Second)
+
 		// add the difference to the date
-		t = t.Add(time.Duration(diff) * time.Hour)
+		next := t.Add(time.Duration(diff) * time.Hour)
+
+		// a DST change between the hours makes the difference too long or too short by its offset change; when the hour is skipped by it, next is the time the clocks jumped to
+		if next.Hour() != i || next.Minute() != 0 {
+			_, before := t.Zone()
+			_, after := next.Zone()
+
+			if adjusted := next.Add(-time.Duration(after-before) * time.Second); adjusted.Hour() == i && adjusted.Minute() == 0 {
+				next = adjusted
+			}
+		}
+
+		t = next
 	}
 
 	// find the first minute matching the expression
@@ -319,13 +640,14 @@ loop:
 			}
 		}
 
-		// if there is no next minute, reset to the next hour
+		// if there is no next minute, reset to the next hour, keeping the offset of t
 		if i >= minuteBitsLen {
-			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location()).Add(1 * time.Hour)
+			t = t.Add(-time.Duration(t.Minute())*time.Minute - time.Duration(t.Second())*time.Second).Add(1 * time.Hour)
 			goto loop
 		}
 
-		// reset not needed (is done at the begining with the truncate)
+		// if the minute value has to be increased, reset the seconds to 0 (the nsec are reset at the begining with the truncate)
+		t = t.Truncate(time.Minute)
 
 		// calculate the difference between the date minute and the next minute in the expression
 		diff := i - int(t.Minute())
@@ -334,5 +656,341 @@ loop:
 		t = t.Add(time.Duration(diff) * time.Minute)
 	}
 
+	// find the first second matching the expression
+	if 1<<t.Second()&s.second == 0 {
+		// get the next second in the bitset
+		var i int
+		for i = t.Second() + 1; i < secondBitsLen; i++ {
+			if s.second&(1<<i) != 0 {
+				break
+			}
+		}
+
+		// if there is no next second, reset to the next minute
+		if i >= secondBitsLen {
+			t = t.Truncate(time.Minute).Add(1 * time.Minute)
+			goto loop
+		}
+
+		// calculate the difference between the date second and the next second in the expression
+		diff := i - int(t.Second())
+
+		// add the difference to the date
+		t = t.Add(time.Duration(diff) * time.Second)
+	}
+
+	return t, nil
+}
+
+// reports whether t is an occurrence of the schedule, in the timezone of the schedule
+//
+// occurrences are whole seconds, so times with a fraction of second never match
+func (s *Cron) Matches(t time.Time) bool {
+	t = t.In(s.tz)
+
+	if t.Nanosecond() != 0 {
+		return false
+	}
+
+	if s.matchesHour(t) && s.minute&(1<<t.Minute()) != 0 && s.second&(1<<t.Second()) != 0 {
+		return true
+	}
+
+	return s.matchesSkipped(t)
+}
+
+// reports whether the year, month, day and hour of t match the expression
+func (s *Cron) matchesHour(t time.Time) bool {
+	year, month, day := t.Date()
+	if s.year != nil && !slices.Contains(s.year, year) {
+		return false
+	}
+
+	return s.month&(1<<month) != 0 &&
+		s.days(year, month)&(1<<day) != 0 &&
+		s.hour&(1<<t.Hour()) != 0
+}
+
+// returns the last time before t that matches the expression in the timezone of the input, or ErrMaxYearLimit if there is none within the year limit
+//
+// like in Next, the times skipped by a DST change are shifted by the length of the change
+func (s *Cron) Prev(t time.Time) (time.Time, error) {
+	prev, err := s.prevWallClock(t)
+
+	limit := prev
+	if err != nil {
+		limit = t.AddDate(-yearLimit, 0, 0)
+	}
+
+	if skipped, ok := s.prevSkipped(t, limit); ok {
+		return skipped, nil
+	}
+
+	return prev, err
+}
+
+// returns the last time before t whose wall clock time matches the expression, in the timezone of the schedule
+func (s *Cron) prevWallClock(t time.Time) (time.Time, error) {
+	t = t.In(s.tz)
+
+	// calculates the min possible year for the loop
+	minYear := t.Year() - yearLimit
+
+	// the last whole second before t (the closest match)
+	t = t.Add(-time.Nanosecond).Truncate(time.Second)
+
+	// every step moves t back to the last second of a previous year, month, day, hour or minute and checks all the fields again, so the loop always ends
+loop:
+	if t.Year() < minYear {
+		return time.Time{}, ErrMaxYearLimit
+	}
+
+	year, month, day := t.Date()
+
+	// find the last year matching the expression
+	if s.year != nil && !slices.Contains(s.year, year) {
+		// get the previous year in the list
+		i, _ := slices.BinarySearch(s.year, year)
+
+		// if there is no previous year, the expression never matched before
+		if i == 0 {
+			return time.Time{}, ErrMaxYearLimit
+		}
+
+		// the year limit is counted from the last matching year
+		minYear = s.year[i-1] - yearLimit
+
+		t = startOfDay(s.year[i-1]+1, time.January, 1, t.Location()).Add(-time.Second)
+		goto loop
+	}
+
+	// find the last month matching the expression
+	if 1<<month&s.month == 0 {
+		prev := s.month & (1<<month - 2)
+
+		// if there is no previous month, go back to the end of the previous year
+		if prev == 0 {
+			t = startOfDay(year, time.January, 1, t.Location()).Add(-time.Second)
+			goto loop
+		}
+
+		t = startOfDay(year, time.Month(bits.Len16(uint16(prev))), 1, t.Location()).Add(-time.Second)
+		goto loop
+	}
+
+	// find the last day matching the expression (day of week and day of month)
+	if days := s.days(year, month); 1<<day&days == 0 {
+		prev := days & (1<<day - 1)
+
+		// if there is no previous day, go back to the end of the previous month
+		if prev == 0 {
+			t = startOfDay(year, month, 1, t.Location()).Add(-time.Second)
+			goto loop
+		}
+
+		t = startOfDay(year, month, bits.Len32(uint32(prev)), t.Location()).Add(-time.Second)
+		goto loop
+	}
+
+	// the hours and minutes are moved back with durations, as Next does, so the repeated hours of DST changes are not skipped
+	startOfMinute := t.Truncate(time.Minute)
+	startOfHour := startOfMinute.Add(-time.Duration(t.Minute()) * time.Minute)
+
+	// the hour started at a DST change skipping less than an hour (e.g. in Australia/Lord_Howe), so moving back the minutes went back to the previous hour
+	if startOfHour.Hour() != t.Hour() {
+		_, before := startOfHour.Zone()
+		_, after := t.Zone()
+		startOfHour = startOfHour.Add(time.Duration(after-before) * time.Second)
+	}
+
+	// find the last hour matching the expression
+	if 1<<t.Hour()&s.hour == 0 {
+		prev := s.hour & (1<<t.Hour() - 1)
+
+		// if there is no previous hour, go back to the end of the previous day
+		if prev == 0 {
+			t = startOfDay(year, month, day, t.Location()).Add(-time.Second)
+			goto loop
+		}
+
+		i := bits.Len32(uint32(prev)) - 1
+		next := startOfHour.Add(-time.Duration(t.Hour()-i-1)*time.Hour - time.Second)
+
+		// a day shortened by a DST change has less time than the difference, so go back one hour at a time not to skip the matching hour
+		if next.Day() != day || next.Hour() != i || next.Minute() != 59 {
+			next = startOfHour.Add(-time.Second)
+		}
+
+		t = next
+		goto loop
+	}
+
+	// find the last minute matching the expression
+	if 1<<t.Minute()&s.minute == 0 {
+		prev := s.minute & (1<<t.Minute() - 1)
+
+		// if there is no previous minute, go back to the end of the previous hour
+		if prev == 0 {
+			t = startOfHour.Add(-time.Second)
+			goto loop
+		}
+
+		i := bits.Len64(uint64(prev)) - 1
+		next := startOfMinute.Add(-time.Duration(t.Minute()-i-1)*time.Minute - time.Second)
+
+		// a DST change of less than an hour skipped or repeated minutes of the hour, so go back one minute at a time not to skip the matching minute
+		if next.Hour() != t.Hour() || next.Minute() != i {
+			next = startOfMinute.Add(-time.Second)
+		}
+
+		t = next
+		goto loop
+	}
+
+	// find the last second matching the expression
+	if 1<<t.Second()&s.second == 0 {
+		prev := s.second & (1<<t.Second() - 1)
+
+		// if there is no previous second, go back to the end of the previous minute
+		if prev == 0 {
+			t = startOfMinute.Add(-time.Second)
+			goto loop
+		}
+
+		t = startOfMinute.Add(time.Duration(bits.Len64(uint64(prev))-1) * time.Second)
+	}
+
 	return t, nil
 }
+
+// returns the nearest occurrence at or before t, or ErrMaxYearLimit if there is none within the year limit
+//
+// it buckets arbitrary timestamps onto the occurrences of the schedule: t itself when it is an occurrence (ignoring the fraction of second), the previous occurrence otherwise
+func (s *Cron) Align(t time.Time) (time.Time, error) {
+	t = t.In(s.tz).Truncate(time.Second)
+
+	if s.Matches(t) {
+		return t, nil
+	}
+
+	return s.Prev(t)
+}
+
+// returns the days of the month matching both the day of month and the day of week fields
+func (s *Cron) days(year int, month time.Month) bitset32 {
+	lastDay := daysIn(year, month)
+
+	dom := s.dom
+	if s.domLast != 0 {
+		for n := 0; n < lastDay; n++ {
+			if s.domLast&(1<<n) != 0 {
+				dom = dom | 1<<(lastDay-n)
+			}
+		}
+	}
+
+	first := firstWeekday(year, month)
+
+	// the nearest weekday never crosses the month boundaries: the 1st on a saturday moves to monday 3rd, the last day on a sunday moves to friday
+	if s.domWeekday != 0 {
+		weekdays := s.domWeekday
+		if weekdays&1 != 0 {
+			weekdays = weekdays | 1<<lastDay
+		}
+
+		for d := 1; d <= lastDay; d++ {
+			if weekdays&(1<<d) == 0 {
+				continue
+			}
+
+			switch (first + d - 1) % 7 {
+			case int(time.Saturday):
+				if d == 1 {
+					dom = dom | 1<<3
+				} else {
+					dom = dom | 1<<(d-1)
+				}
+			case int(time.Sunday):
+				if d == lastDay {
+					dom = dom | 1<<(d-2)
+				} else {
+					dom = dom | 1<<(d+1)
+				}
+			default:
+				dom = dom | 1<<d
+			}
+		}
+	}
+
+	// rotate the weekdays so that bit 0 is the weekday of the first day of the month, and repeat them along the month
+	week := (bitset32(s.dow)>>first | bitset32(s.dow)<<(7-first)) & 0x7f
+	dow := (week | week<<7 | week<<14 | week<<21 | week<<28) << 1
+
+	// the nth occurrence of a weekday is n-1 weeks after its first occurrence, if the month is long enough
+	for w, nth := range s.dowNth {
+		if nth == 0 {
+			continue
+		}
+
+		firstDay := 1 + (w-first+7)%7
+		for n := 1; n <= 5; n++ {
+			if day := firstDay + 7*(n-1); nth&(1<<n) != 0 && day <= lastDay {
+				dow = dow | 1<<day
+			}
+		}
+	}
+
+	// the last occurrence of a weekday is within the last 7 days of the month
+	if s.dowLast != 0 {
+		lastWeekday := (first + lastDay - 1) % 7
+		for w := 0; w < 7; w++ {
+			if s.dowLast&(1<<w) != 0 {
+				dow = dow | 1<<(lastDay-(lastWeekday-w+7)%7)
+			}
+		}
+	}
+
+	// keep only the days of the month (bits 1 to lastDay)
+	return dom & dow & (1<<(lastDay+1) - 2)
+}
+
+// returns the first time of the day, or of the next one when the day doesn't exist
+//
+// time.Date may return a time of the previous day when midnight is skipped by a DST change (e.g. in America/Santiago), so the time moves forward to the first wall clock hour of the day
+func startOfDay(year int, month time.Month, day int, loc *time.Location) time.Time {
+	t := time.Date(year, month, day, 0, 0, 0, 0, loc)
+
+	// the whole day may be skipped too (e.g. in Pacific/Apia), so stop at any later day
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	for y, m, d := t.Date(); time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Before(date); y, m, d = t.Date() {
+		t = t.Add(time.Hour - time.Duration(t.Minute())*time.Minute)
+	}
+
+	return t
+}
+
+// returns the weekday of the first day of the month (Sakamoto's method), avoiding the cost of building a time.Time
+func firstWeekday(year int, month time.Month) int {
+	offsets := [...]int{0, 3, 2, 5, 0, 3, 5, 1, 4, 6, 2, 4}
+	if month < time.March {
+		year--
+	}
+
+	return (year + year/4 - year/100 + year/400 + offsets[month-1] + 1) % 7
+}
+
+// returns the number of days of the month
+func daysIn(year int, month time.Month) int {
+	switch month {
+	case time.February:
+		if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+			return 29
+		}
+
+		return 28
+	case time.April, time.June, time.September, time.November:
+		return 30
+	}
+
+	return 31
+}
@@ -1,6 +1,11 @@
 package cron
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"math/rand"
+	"strings"
 	"testing"
 	"time"
 )
@@ -11,3 +16,1793 @@ func BenchmarkNext(b *testing.B) {
 		c.Next(time.Now())
 	}
 }
+
+func BenchmarkNextFastPath(b *testing.B) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	if err != nil {
+		b.Skip(err)
+	}
+
+	t := time.Date(2024, 6, 12, 10, 17, 31, 0, madrid)
+
+	for _, expr := range []string{"* * * * *", "*/15 * * * *", "30 9 * * *"} {
+		c := MustParse(expr, madrid)
+
+		// the same schedule without the fast path
+		generic := *c
+		generic.fast = fastPath{}
+
+		b.Run(expr, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				c.Next(t)
+			}
+		})
+
+		b.Run(expr+" generic", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				generic.Next(t)
+			}
+		})
+	}
+}
+
+func TestBuilder(t *testing.T) {
+	if _, err := Minute(75); err != ErrOutOfRange {
+		t.Fatalf("Minute(75): expected ErrOutOfRange, got %v", err)
+	}
+
+	m, _ := Minute(30)
+	h, _ := Hour(9)
+
+	c, err := NewBuilder(time.UTC).Minutes(m).Hours(h).Weekdays(time.Monday).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := c.Next(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := time.Date(2024, 1, 8, 9, 30, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	// the typed values are plain integers, so they are checked again
+	for name, b := range map[string]*Builder{
+		"Minutes(75)": NewBuilder(time.UTC).Minutes(75),
+		"Hours(24)":   NewBuilder(time.UTC).Hours(24),
+		"Days(0)":     NewBuilder(time.UTC).Days(0),
+	} {
+		if _, err := b.Build(); err != ErrOutOfRange {
+			t.Errorf("%s: expected ErrOutOfRange, got %v", name, err)
+		}
+	}
+}
+
+func TestAccessors(t *testing.T) {
+	c := MustParse("0 0 * 1,6 0", time.UTC)
+
+	if got := c.Months(); len(got) != 2 || got[0] != time.January || got[1] != time.June {
+		t.Fatalf("unexpected months %v", got)
+	}
+
+	if got := c.Weekdays(); len(got) != 1 || got[0] != time.Sunday {
+		t.Fatalf("unexpected weekdays %v", got)
+	}
+
+	if _, err := NewBuilder(time.UTC).Weekdays(7).Build(); err != ErrOutOfRange {
+		t.Fatalf("expected ErrOutOfRange, got %v", err)
+	}
+}
+
+func TestFromTaskTrigger(t *testing.T) {
+	c, err := FromTaskTrigger(TaskTrigger{
+		Type:          TaskMonthly,
+		StartBoundary: time.Date(2024, 1, 1, 8, 15, 0, 0, time.UTC),
+		DaysOfMonth:   []int{1, 15},
+		Months:        []time.Month{time.March},
+	}, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, _ := c.Next(time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC))
+	want := time.Date(2024, 3, 15, 8, 15, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	if _, err := FromTaskTrigger(TaskTrigger{Type: TaskDaily, DaysInterval: 2}, time.UTC); err != ErrUnsupportedTrigger {
+		t.Fatalf("expected ErrUnsupportedTrigger, got %v", err)
+	}
+}
+
+func TestPresets(t *testing.T) {
+	for name := range Presets {
+		if _, err := Preset(name, time.UTC); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+
+	c, err := WeeklyOn(time.Monday, "8:00", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, _ := c.Next(time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC))
+	want := time.Date(2024, 1, 8, 8, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	if _, err := FixedRate(7*time.Minute, time.UTC); err != ErrUnsupportedRate {
+		t.Fatalf("expected ErrUnsupportedRate, got %v", err)
+	}
+}
+
+func TestTickerStopsWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := MustParse("* * * * *", time.UTC).Ticker(ctx)
+	cancel()
+
+	select {
+	case _, ok := <-c:
+		if ok {
+			t.Fatal("unexpected occurrence")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the channel was not closed")
+	}
+}
+
+func TestTickerWithMeta(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, err := NewParser(WithSeconds()).Parse("* * * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := TickerWithMeta(ctx, s, func(t time.Time) string {
+		return t.Format("15:04:05")
+	})
+
+	select {
+	case a := <-c:
+		if a.Meta != a.Time.Format("15:04:05") {
+			t.Fatalf("expected the metadata of %v, got %q", a.Time, a.Meta)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("no occurrence was delivered")
+	}
+
+	cancel()
+	for range c {
+	}
+}
+
+func TestAfterFunc(t *testing.T) {
+	if _, err := AfterFunc("* * *", time.UTC, func(time.Time) {}); !errors.Is(err, ErrInvalidExpression) {
+		t.Fatalf("expected ErrInvalidExpression, got %v", err)
+	}
+
+	h, err := AfterFunc("* * * * *", time.UTC, func(time.Time) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !h.Stop() || h.Stop() {
+		t.Fatal("expected only the first Stop to report true")
+	}
+}
+
+func TestParseUntrusted(t *testing.T) {
+	cases := []struct {
+		expr   string
+		policy Policy
+		err    error
+	}{
+		{"*/5 * * * *", DefaultPolicy, nil},
+		{"*/0 * * * *", DefaultPolicy, ErrInvalidExpression},
+		{"0 0 30 2 *", DefaultPolicy, ErrInvalidExpression},
+		{"0 0 30 2 *", Policy{}, nil},
+		{"* * * * *", Policy{MinInterval: 5 * time.Minute}, ErrIntervalTooShort},
+		{"55 * * * *", Policy{MinInterval: time.Hour}, nil},
+		{"0,55 * * * *", Policy{MinInterval: 10 * time.Minute}, ErrIntervalTooShort},
+		{"0 23,0 * * *", Policy{MinInterval: 2 * time.Hour}, ErrIntervalTooShort},
+		{"1,2,3 * * * *", Policy{MaxListItems: 2}, ErrTooManyListItems},
+		{"*/5 * * * *", Policy{AllowedCharacters: "*"}, ErrCharacterNotAllowed},
+		{"* * * * *", Policy{MaxLength: 5}, ErrExpressionTooLong},
+		{"0 * * * * *", Policy{MaxFields: 5}, ErrTooManyFields},
+		{"TZ=UTC * * * * *", Policy{MaxFields: 5}, nil},
+		{"0 0 L * *", Policy{AllowedSpecials: []Special{}}, ErrSpecialNotAllowed},
+		{"0 0 L * *", Policy{AllowedSpecials: []Special{SpecialLast}}, nil},
+		{"0 0 LW * *", Policy{AllowedSpecials: []Special{SpecialLast}}, ErrSpecialNotAllowed},
+		{"0 0 15W * *", Policy{AllowedSpecials: []Special{SpecialWeekday}}, nil},
+		{"0 0 * * 5#2", Policy{AllowedSpecials: []Special{SpecialLast}}, ErrSpecialNotAllowed},
+		{"0 0 * * 5#2", Policy{AllowedSpecials: []Special{SpecialNth}}, nil},
+		{"0 0 ? * 1", Policy{AllowedSpecials: []Special{}}, ErrSpecialNotAllowed},
+		{"@daily", Policy{AllowedSpecials: []Special{SpecialName}}, ErrSpecialNotAllowed},
+		{"@daily", Policy{AllowedSpecials: []Special{SpecialMacro}}, nil},
+		{"TZ=UTC * * * * *", Policy{AllowedSpecials: []Special{}}, ErrSpecialNotAllowed},
+		{"TZ=UTC * * * * *", Policy{AllowedSpecials: []Special{SpecialZone}}, nil},
+		{"0 0 * JUL *", Policy{AllowedSpecials: []Special{SpecialLast}}, ErrSpecialNotAllowed},
+		{"0 0 * JUL MON-FRI", Policy{AllowedSpecials: []Special{SpecialName}}, nil},
+		{"0 0 * * FRIL", Policy{AllowedSpecials: []Special{SpecialName}}, ErrSpecialNotAllowed},
+		{"0 0 * * FRIL", Policy{AllowedSpecials: []Special{SpecialName, SpecialLast}}, nil},
+		{"*/5 1-3 * * *", Policy{AllowedSpecials: []Special{}}, nil},
+		{"* * * * *", Policy{AllowedLocations: []string{"Europe/Madrid"}}, ErrLocationNotAllowed},
+		{"TZ=UTC * * * * *", Policy{AllowedLocations: []string{"Europe/Madrid"}}, ErrLocationNotAllowed},
+		{"* * * * *", Policy{IANALocationsOnly: true}, nil},
+	}
+
+	for _, c := range cases {
+		if _, err := ParseUntrusted(c.expr, time.UTC, c.policy); !errors.Is(err, c.err) {
+			t.Errorf("%q: expected %v, got %v", c.expr, c.err, err)
+		}
+	}
+
+	_, err := ParseUntrusted("* * * * *", time.FixedZone("UTC+2", 2*60*60), Policy{IANALocationsOnly: true})
+
+	var locErr *LocationError
+	if !errors.As(err, &locErr) || locErr.Location != "UTC+2" {
+		t.Errorf("expected a *LocationError for UTC+2, got %v", err)
+	}
+}
+
+func TestExpressionTooComplex(t *testing.T) {
+	long := strings.Repeat(" ", MaxExpressionLength) + "* * * * *"
+	list := strings.TrimSuffix(strings.Repeat("0,", MaxListItems+1), ",")
+	parts := strings.TrimSuffix(strings.Repeat("1,", MaxListItems), ",")
+
+	cases := []struct {
+		expr string
+		err  error
+	}{
+		{long, ErrExpressionTooComplex},
+		{list + " * * * *", ErrExpressionTooComplex},
+		{parts + " " + parts + " * * *", ErrExpressionTooComplex},
+		{parts + " * * * *", nil},
+		{"0 0 " + strings.Repeat("1-31/100,", 50) + "1 * *", nil},
+	}
+
+	for _, c := range cases {
+		if _, err := Parse(c.expr, time.UTC); !errors.Is(err, c.err) {
+			t.Errorf("%.20q: expected %v, got %v", c.expr, c.err, err)
+		}
+	}
+
+	for _, parse := range []func(string, *time.Location) (*Cron, error){ParseQuartz, ParseSystemd, FromRRule, ParseNatural} {
+		if _, err := parse(long, time.UTC); !errors.Is(err, ErrExpressionTooComplex) {
+			t.Errorf("expected ErrExpressionTooComplex, got %v", err)
+		}
+	}
+
+	if _, err := ParseQuartz("0 "+list+" * * * ?", time.UTC); !errors.Is(err, ErrExpressionTooComplex) {
+		t.Errorf("expected ErrExpressionTooComplex, got %v", err)
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, expr := range []string{"* * * * *", "*/5 9-17 * * 1-5", "0 0 L-3,15W * 5#2,1L 2026-2030/2", "CRON_TZ=Europe/Madrid @daily", "~ ~3 * * *", "0-59/0 * * * *"} {
+		f.Add(expr)
+	}
+
+	f.Fuzz(func(t *testing.T, expr string) {
+		c, err := Parse(expr, time.UTC)
+		if err != nil {
+			return
+		}
+
+		// the expression of a schedule parses back into the same schedule
+		back, err := Parse(c.String(), time.UTC)
+		if err != nil || back.String() != c.String() {
+			t.Fatalf("%q: %q parses back into %v (%v)", expr, c.String(), back, err)
+		}
+
+		c.Describe("en")
+	})
+}
+
+func TestWriteOccurrencesCSV(t *testing.T) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	var b bytes.Buffer
+	err = WriteOccurrencesCSV(&b, map[string]*Cron{
+		"b": MustParse("0 12 * * *", madrid),
+		"a": MustParse("0 0 * * *", time.UTC),
+	}, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "id,time,tz,local\n" +
+		"a,2024-01-01T00:00:00Z,UTC,2024-01-01T00:00:00\n" +
+		"b,2024-01-01T11:00:00Z,Europe/Madrid,2024-01-01T12:00:00\n"
+	if b.String() != want {
+		t.Fatalf("expected %q, got %q", want, b.String())
+	}
+}
+
+func TestWriteOccurrencesSQL(t *testing.T) {
+	var b bytes.Buffer
+	err := WriteOccurrencesSQL(&b, "jobs.queue", map[string]*Cron{
+		"o'clock": MustParse("0 * * * *", time.UTC),
+	}, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `INSERT INTO "jobs"."queue" (id, scheduled_at, tz, local_time) VALUES ('o''clock', '2024-01-01T00:00:00Z', 'UTC', '2024-01-01T00:00:00');` + "\n"
+	if b.String() != want {
+		t.Fatalf("expected %q, got %q", want, b.String())
+	}
+}
+
+func TestString(t *testing.T) {
+	cases := map[string]string{
+		"* * * * *":            "* * * * *",
+		"*/20 0-3,5 1,2 6 1-5": "0,20,40 0-3,5 1-2 6 1-5",
+	}
+
+	for expr, want := range cases {
+		if got := MustParse(expr, time.UTC).String(); got != want {
+			t.Errorf("%q: expected %q, got %q", expr, want, got)
+		}
+	}
+
+	// the seconds are written with the year, so they are read back as seconds
+	for _, expr := range []string{"*/30 * * * * *", "15 0 9 * * * 2030"} {
+		c, err := NewParser(WithSeconds()).Parse(expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		text, err := c.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var back Cron
+		if err := back.UnmarshalText(text); err != nil {
+			t.Fatalf("%q: %v", text, err)
+		}
+
+		if again, _ := back.MarshalText(); string(again) != string(text) {
+			t.Errorf("%q: expected %q to round trip, got %q", expr, text, again)
+		}
+	}
+}
+
+func TestYearField(t *testing.T) {
+	c := MustParse("0 0 1 1 * 2026,2030-2031", time.UTC)
+
+	want := []time.Time{
+		time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	next := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	for _, w := range want {
+		var err error
+		next, err = c.Next(next)
+		if err != nil || !next.Equal(w) {
+			t.Fatalf("expected %v, got %v, %v", w, next, err)
+		}
+	}
+
+	if _, err := c.Next(next); err != ErrMaxYearLimit {
+		t.Fatalf("expected ErrMaxYearLimit, got %v", err)
+	}
+
+	if got := c.String(); got != "0 0 1 1 * 2026,2030-2031" {
+		t.Fatalf("unexpected string %q", got)
+	}
+
+	if _, err := Parse("0 0 1 1 * 1969", time.UTC); !errors.Is(err, ErrInvalidExpression) {
+		t.Fatalf("expected ErrInvalidExpression, got %v", err)
+	}
+}
+
+func TestParseErrorSuggestions(t *testing.T) {
+	cases := map[string]string{
+		"60 * * * *":     "invalid cron expression: minute field: '60' is out of range 0-59 (did you mean 0?)",
+		"* 5-1 * * *":    "invalid cron expression: hour field: range '5-1' is reversed (did you mean 1-5?)",
+		"* * 32 * *":     "invalid cron expression: day of month field: '32' is out of range 1-31 (did you mean 31?)",
+		"* * * MARCH *":  "invalid cron expression: month field: 'MARCH' is not a valid value (use MAR)",
+		"* * * * MONDAY": "invalid cron expression: day of week field: 'MONDAY' is not a valid value (use MON)",
+		"* * * * 1,?":    "invalid cron expression: day of week field: '?' is not a valid value",
+	}
+
+	for expr, want := range cases {
+		_, err := Parse(expr, time.UTC)
+
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) || err.Error() != want {
+			t.Errorf("%q: expected %q, got %v", expr, want, err)
+		}
+	}
+}
+
+func TestMacros(t *testing.T) {
+	cases := map[string]string{
+		"@yearly":  "0 0 1 1 *",
+		"@monthly": "0 0 1 * *",
+		"@weekly":  "0 0 * * 0",
+		"@daily":   "0 0 * * *",
+		"@hourly":  "0 * * * *",
+	}
+
+	for macro, want := range cases {
+		if got := MustParse(macro, time.UTC).String(); got != want {
+			t.Errorf("%s: expected %q, got %q", macro, want, got)
+		}
+	}
+
+	if _, err := Parse("@reboot", time.UTC); !errors.Is(err, ErrInvalidExpression) {
+		t.Fatalf("expected ErrInvalidExpression, got %v", err)
+	}
+}
+
+func TestParseLenient(t *testing.T) {
+	c, corrections, err := ParseLenient(" 50-60 5-1 * * * /usr/bin/backup --all ", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := c.String(); got != "50-59 1-5 * * *" {
+		t.Fatalf("unexpected expression %q", got)
+	}
+
+	want := []Correction{
+		{Field: "minute", Original: "50-60", Corrected: "50-59"},
+		{Field: "hour", Original: "5-1", Corrected: "1-5"},
+		{Original: "/usr/bin/backup"},
+		{Original: "--all"},
+	}
+	if len(corrections) != len(want) {
+		t.Fatalf("expected %v, got %v", want, corrections)
+	}
+
+	for i := range want {
+		if corrections[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, corrections)
+		}
+	}
+
+	if _, _, err := ParseLenient("70 * * * *", time.UTC); !errors.Is(err, ErrInvalidExpression) {
+		t.Fatalf("expected ErrInvalidExpression, got %v", err)
+	}
+
+	// 60 minutes and 24 hours are carried over to the next unit, unless it changes other times
+	for expr, want := range map[string]string{
+		"60 9 * * *":    "0 10 * * *",
+		"60 * * * 1-5":  "0 * * * 1-5",
+		"0 24 * * *":    "0 0 * * *",
+		"60 23 * * *":   "",
+		"60 9,17 * * *": "",
+		"0,60 9 * * *":  "",
+		"0 24 1 * *":    "",
+		"0 24 * * MON":  "",
+		"0 0 * * 8":     "",
+		"0 0 32 * *":    "",
+		"0 0 1 13 *":    "",
+		"0 0 1,32 * *":  "",
+		"0 0 20-32 * *": "0 0 20-31 * *",
+		"0 0 1 10-13 *": "0 0 1 10-12 *",
+	} {
+		c, _, err := ParseLenient(expr, time.UTC)
+		if want == "" {
+			if !errors.Is(err, ErrInvalidExpression) {
+				t.Errorf("%q: expected ErrInvalidExpression, got %v", expr, c)
+			}
+
+			continue
+		}
+
+		if err != nil || c.String() != want {
+			t.Errorf("%q: expected %q, got %v, %v", expr, want, c, err)
+		}
+	}
+
+	// the timezone prefix is not a field
+	c, corrections, err = ParseLenient("CRON_TZ=UTC+02:00 30 9 * * 5-1", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := c.String(); got != "CRON_TZ=UTC+02:00 30 9 * * 1-5" || len(corrections) != 1 {
+		t.Fatalf("unexpected expression %q with %v", got, corrections)
+	}
+
+	for _, expr := range []string{"CRON_TZ=Nope/Zone 0 0 * * *", "TZ= 0 0 * * *", "CRON_TZ=Nope/Zone @daily"} {
+		if _, _, err := ParseLenient(expr, time.UTC); !errors.Is(err, ErrInvalidExpression) {
+			t.Fatalf("%q: expected ErrInvalidExpression, got %v", expr, err)
+		}
+	}
+}
+
+func TestNames(t *testing.T) {
+	cases := map[string]string{
+		"0 0 1 JAN,mar-Jun *": "0 0 1 1,3-6 *",
+		"0 0 * * MON-FRI":     "0 0 * * 1-5",
+		"0 0 * * sun,Sat":     "0 0 * * 0,6",
+		"0 0 * * 7":           "0 0 * * 0",
+		"0 0 * * 5-7":         "0 0 * * 0,5-6",
+		"0 0 * * */2":         "0 0 * * 0,2,4,6",
+		"0 0 * * FRIL,1":      "0 0 * * 1,5L",
+		"0 0 * * 7L":          "0 0 * * 0L",
+		"0 0 * * fri#2,7#1":   "0 0 * * 0#1,5#2",
+		"0 0 15 * ?":          "0 0 15 * *",
+		"0 0 ? * MON":         "0 0 * * 1",
+	}
+
+	for expr, want := range cases {
+		if got := MustParse(expr, time.UTC).String(); got != want {
+			t.Errorf("%q: expected %q, got %q", expr, want, got)
+		}
+	}
+}
+
+func TestParseSchedule(t *testing.T) {
+	s, err := ParseSchedule("0 9 * * 1-5; 0 10 * * 6\n0 11 * * 0", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 2024-01-05 is a Friday
+	want := []time.Time{
+		time.Date(2024, 1, 6, 10, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 7, 11, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC),
+	}
+
+	next := time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC)
+	for _, w := range want {
+		next, err = s.Next(next)
+		if err != nil || !next.Equal(w) {
+			t.Fatalf("expected %v, got %v, %v", w, next, err)
+		}
+	}
+
+	if _, ok := MustParseSchedule("0 9 * * *", time.UTC).(*Cron); !ok {
+		t.Fatal("expected a single expression to return a *Cron")
+	}
+}
+
+func TestExceptSchedule(t *testing.T) {
+	for _, expr := range []string{"0 * * * * except: 0 2-4 * * *", "0 * * * * except 0 2-4 * * *", "0 * * * *; !0 2-4 * * *"} {
+		s, err := ParseSchedule(expr, time.UTC)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, _ := s.Next(time.Date(2024, 1, 1, 1, 30, 0, 0, time.UTC))
+		want := time.Date(2024, 1, 1, 5, 0, 0, 0, time.UTC)
+		if !got.Equal(want) {
+			t.Fatalf("%q: expected %v, got %v", expr, want, got)
+		}
+	}
+
+	// sunday 7th of january 2024 is skipped
+	s := MustParse("0 9 * * *", time.UTC).Except(MustParse("0 9 * * SUN", time.UTC))
+	if got, _ := s.Next(time.Date(2024, 1, 6, 10, 0, 0, 0, time.UTC)); !got.Equal(time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected sunday to be skipped, got %v", got)
+	}
+
+	if _, err := MustParseSchedule("0 * * * * except: * * * * *", time.UTC).Next(time.Now()); err != ErrMaxYearLimit {
+		t.Fatalf("expected ErrMaxYearLimit, got %v", err)
+	}
+}
+
+func TestEvery(t *testing.T) {
+	anchor := time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC)
+
+	s, err := Every(72*time.Hour, anchor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[time.Time]time.Time{
+		time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC):    time.Date(2024, 1, 4, 6, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC):    time.Date(2024, 1, 7, 6, 0, 0, 0, time.UTC),
+		time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC):  time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC),
+		time.Date(2023, 12, 29, 6, 0, 0, 0, time.UTC):  time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC),
+		time.Date(2023, 12, 28, 12, 0, 0, 0, time.UTC): time.Date(2023, 12, 29, 6, 0, 0, 0, time.UTC),
+	}
+
+	for from, want := range cases {
+		if got, _ := s.Next(from); !got.Equal(want) {
+			t.Errorf("%v: expected %v, got %v", from, want, got)
+		}
+	}
+
+	if _, err := Every(0, anchor); err != ErrInvalidInterval {
+		t.Fatalf("expected ErrInvalidInterval, got %v", err)
+	}
+}
+
+// reports whether t matches the schedule by checking every field, as a reference for Next
+func naiveMatch(s *Cron, t time.Time) bool {
+	lastDay := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+
+	// the day of month fields matching the nearest weekday to t
+	nearest := false
+	for d := 1; d <= lastDay; d++ {
+		if s.domWeekday&(1<<d) == 0 && (d != lastDay || s.domWeekday&1 == 0) {
+			continue
+		}
+
+		day := d
+		switch time.Date(t.Year(), t.Month(), d, 0, 0, 0, 0, t.Location()).Weekday() {
+		case time.Saturday:
+			if day--; day == 0 {
+				day = 3
+			}
+		case time.Sunday:
+			if day++; day > lastDay {
+				day = lastDay - 2
+			}
+		}
+
+		nearest = nearest || day == t.Day()
+	}
+
+	return s.second&(1<<t.Second()) != 0 && t.Nanosecond() == 0 &&
+		s.minute&(1<<t.Minute()) != 0 &&
+		s.hour&(1<<t.Hour()) != 0 &&
+		(s.dom&(1<<t.Day()) != 0 || s.domLast&(1<<(lastDay-t.Day())) != 0 || nearest) &&
+		s.month&(1<<int(t.Month())) != 0 &&
+		(s.dow&(1<<int(t.Weekday())) != 0 ||
+			s.dowLast&(1<<int(t.Weekday())) != 0 && t.Day()+7 > lastDay ||
+			s.dowNth[t.Weekday()]&(1<<((t.Day()+6)/7)) != 0)
+}
+
+func TestNextMatchesNaive(t *testing.T) {
+	exprs := []string{
+		"59 23 1 * 1",
+		"*/7 1,13 * * *",
+		"0 0 L * *",
+		"30 12 L,15 2 *",
+		"0 0 L-3,L-30 * *",
+		"0 0 29 2 *",
+		"0 0 * * 6",
+		"0 12 13 * 5",
+		"0 18 * * 5L",
+		"0 18 * * 1,0L",
+		"0 18 25-31 * 7L",
+		"0 9 1W,15W,31W * *",
+		"0 9 30W,L * 1-5",
+		"0 17 LW * *",
+		"0 9 * * FRI#2,1#5",
+	}
+
+	from := time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	for _, expr := range exprs {
+		c := MustParse(expr, time.UTC)
+
+		next, err := c.Next(from)
+		if err != nil {
+			t.Fatalf("%q: %v", expr, err)
+		}
+
+		for m := from.Add(time.Minute); m.Before(to); m = m.Add(time.Minute) {
+			if naiveMatch(c, m) != m.Equal(next) {
+				t.Fatalf("%q: expected %v to be the next occurrence, got %v", expr, m, next)
+			}
+
+			if m.Equal(next) {
+				if next, err = c.Next(next); err != nil {
+					t.Fatalf("%q: %v", expr, err)
+				}
+			}
+		}
+	}
+}
+
+func TestNearestWeekday(t *testing.T) {
+	cases := map[string]time.Time{
+		// 2024-06-01 is a saturday, the nearest weekday within the month is monday 3rd
+		"0 9 1W * *": time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC),
+		// 2024-06-15 is a saturday
+		"0 9 15W * *": time.Date(2024, 6, 14, 9, 0, 0, 0, time.UTC),
+		// 2024-06-30 is a sunday, the nearest weekday within the month is friday 28th
+		"0 9 30W * *": time.Date(2024, 6, 28, 9, 0, 0, 0, time.UTC),
+	}
+
+	for expr, want := range cases {
+		got, err := MustParse(expr, time.UTC).Next(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+		if err != nil || !got.Equal(want) {
+			t.Errorf("%q: expected %v, got %v, %v", expr, want, got, err)
+		}
+	}
+}
+
+func TestParseQuartz(t *testing.T) {
+	cases := []struct {
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		// the third friday of the month, every 15 seconds of 10:30
+		{"0/15 30 10 ? * 6#3", time.Date(2024, 6, 21, 10, 30, 15, 0, time.UTC), time.Date(2024, 6, 21, 10, 30, 30, 0, time.UTC)},
+		{"0/15 30 10 ? * 6#3", time.Date(2024, 6, 21, 10, 30, 45, 0, time.UTC), time.Date(2024, 7, 19, 10, 30, 0, 0, time.UTC)},
+		{"0 0 12 L * ?", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC)},
+		{"0 0 12 ? * L", time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 8, 12, 0, 0, 0, time.UTC)},
+		{"0 0 12 ? * 2-6 2025", time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{"30 0 12 15W * ?", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 14, 12, 0, 30, 0, time.UTC)},
+	}
+
+	for _, c := range cases {
+		got, err := MustParseQuartz(c.expr, time.UTC).Next(c.from)
+		if err != nil || !got.Equal(c.want) {
+			t.Errorf("%q from %v: expected %v, got %v, %v", c.expr, c.from, c.want, got, err)
+		}
+	}
+
+	for _, expr := range []string{"0 0 12 1 * 2", "0 0 12 ? * ?", "0 0 12 * * *", "0 12 * * ?"} {
+		if _, err := ParseQuartz(expr, time.UTC); !errors.Is(err, ErrInvalidExpression) {
+			t.Errorf("%q: expected ErrInvalidExpression, got %v", expr, err)
+		}
+	}
+
+	if got := MustParseQuartz("*/20 0 12 ? * MON-FRI", time.UTC).String(); got != "0,20,40 0 12 * * 1-5 *" {
+		t.Fatalf("unexpected expression %q", got)
+	}
+}
+
+func TestRandomRanges(t *testing.T) {
+	c := MustParse("~ 1~5 * * SUN~", time.UTC)
+
+	if m := c.Minutes(); len(m) != 1 {
+		t.Fatalf("unexpected minutes %v", m)
+	}
+
+	if h := c.Hours(); len(h) != 1 || h[0] < 1 || h[0] > 5 {
+		t.Fatalf("unexpected hours %v", h)
+	}
+
+	a, _ := ParseWithRand("~ ~ * * *", time.UTC, rand.New(rand.NewSource(42)))
+	b, _ := ParseWithRand("~ ~ * * *", time.UTC, rand.New(rand.NewSource(42)))
+	if a.String() != b.String() {
+		t.Fatalf("expected the same seed to give the same schedule, got %q and %q", a, b)
+	}
+
+	if _, err := Parse("5~1 * * * *", time.UTC); !errors.Is(err, ErrInvalidExpression) {
+		t.Fatalf("expected ErrInvalidExpression, got %v", err)
+	}
+}
+
+func TestTimezonePrefix(t *testing.T) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	for _, expr := range []string{"CRON_TZ=Europe/Madrid 30 6 * * *", "TZ=Europe/Madrid @daily"} {
+		if c := MustParse(expr, time.UTC); c.Location().String() != madrid.String() {
+			t.Fatalf("%q: expected %v, got %v", expr, madrid, c.Location())
+		}
+	}
+
+	if _, err := Parse("CRON_TZ=Mars/Olympus 30 6 * * *", time.UTC); !errors.Is(err, ErrInvalidExpression) {
+		t.Fatalf("expected ErrInvalidExpression, got %v", err)
+	}
+
+	// an empty name is not UTC
+	for _, expr := range []string{"CRON_TZ= 0 0 * * *", "TZ= 0 0 * * *"} {
+		var parseErr *ParseError
+		if _, err := Parse(expr, madrid); !errors.As(err, &parseErr) || parseErr.Field != "timezone" {
+			t.Fatalf("%q: expected a timezone ParseError, got %v", expr, err)
+		}
+	}
+}
+
+func TestParseWithOptions(t *testing.T) {
+	c, err := ParseWithOptions("30 0 9 * * MON", WithSeconds())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	next, _ := c.Next(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	if want := time.Date(2024, 1, 1, 9, 0, 30, 0, time.UTC); !next.Equal(want) {
+		t.Fatalf("expected %v, got %v", want, next)
+	}
+
+	if c, err := NewParser(WithSeconds()).Parse("@daily"); err != nil || c.String() != "0 0 * * *" {
+		t.Fatalf("expected macros to run at second 0, got %v, %v", c, err)
+	}
+
+	if _, err := ParseWithOptions("@daily", WithoutMacros()); !errors.Is(err, ErrInvalidExpression) {
+		t.Fatalf("expected ErrInvalidExpression, got %v", err)
+	}
+
+	if c, _ := ParseWithOptions("0 0 * * *"); c.Location() != time.UTC {
+		t.Fatalf("expected UTC by default, got %v", c.Location())
+	}
+
+	for _, expr := range []string{"1,1-5 * * * *", "0 0 * * 0,7", "0-10/20 * * * *", "0 0 30 2 *", "0 0 31 4,6 *"} {
+		if _, err := ParseWithOptions(expr, WithStrict()); !errors.Is(err, ErrInvalidExpression) {
+			t.Fatalf("%q: expected ErrInvalidExpression, got %v", expr, err)
+		}
+	}
+
+	for _, expr := range []string{"*/15 9-17 * * *", "0 0 L * 1-5,SAT", "0 0 * * *", "0 0 29 2 *", "0 0 31 4,5 *"} {
+		if _, err := ParseWithOptions(expr, WithStrict()); err != nil {
+			t.Fatalf("%q: unexpected error %v", expr, err)
+		}
+	}
+}
+
+func TestFieldLayouts(t *testing.T) {
+	p := NewParser(WithFields(FieldHour | FieldDayOfWeek))
+
+	c, err := p.Parse("9 MON-FRI")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := c.String(); got != "0 9 * * 1-5" {
+		t.Fatalf("unexpected expression %q", got)
+	}
+
+	if _, err := p.Parse("0 9 * * 1-5"); !errors.Is(err, ErrInvalidExpression) {
+		t.Fatalf("expected ErrInvalidExpression, got %v", err)
+	}
+
+	p = NewParser(WithFields(FieldSecondOptional | FieldMinute | FieldHour | FieldDayOfMonth | FieldMonth | FieldDayOfWeek))
+
+	for expr, want := range map[string]string{"30 0 9 * * *": "30 0 9 * * * *", "0 9 * * *": "0 9 * * *"} {
+		c, err := p.Parse(expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := c.String(); got != want {
+			t.Fatalf("%q: expected %q, got %q", expr, want, got)
+		}
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("expected a panic for two optional fields")
+			}
+		}()
+
+		WithFields(FieldSecondOptional | FieldMinute | FieldYearOptional)
+	}()
+}
+
+func TestZoneProvider(t *testing.T) {
+	office := time.FixedZone("Office", 2*60*60)
+	zones := ZoneProviderFunc(func(name string) (*time.Location, error) {
+		if name == "Office" {
+			return office, nil
+		}
+
+		return nil, errors.New("unknown zone")
+	})
+
+	p := NewParser(WithZoneProvider(zones))
+
+	c, err := p.Parse("CRON_TZ=Office 0 9 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Location() != office {
+		t.Fatalf("expected %v, got %v", office, c.Location())
+	}
+
+	if _, err := p.Parse("CRON_TZ=UTC 0 9 * * *"); !errors.Is(err, ErrInvalidExpression) {
+		t.Fatalf("expected ErrInvalidExpression, got %v", err)
+	}
+}
+
+func TestFixedOffsets(t *testing.T) {
+	cases := map[string]int{
+		"CRON_TZ=UTC+05:30 0 9 * * *": 5*60*60 + 30*60,
+		"TZ=UTC-3 0 9 * * *":          -3 * 60 * 60,
+		"CRON_TZ=GMT+0100 0 9 * * *":  60 * 60,
+	}
+
+	for expr, offset := range cases {
+		c, err := Parse(expr, time.UTC)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, got := time.Date(2024, 1, 1, 0, 0, 0, 0, c.Location()).Zone(); got != offset {
+			t.Fatalf("%q: expected offset %d, got %d", expr, offset, got)
+		}
+
+		again := MustParse(c.String(), time.UTC)
+		if again.String() != c.String() {
+			t.Fatalf("%q: expected %q to round trip, got %q", expr, c, again)
+		}
+	}
+
+	if _, err := Parse("CRON_TZ=UTC+25:00 0 9 * * *", time.UTC); !errors.Is(err, ErrInvalidExpression) {
+		t.Fatalf("expected ErrInvalidExpression, got %v", err)
+	}
+
+	text, _ := MustParse("0 9 * * *", time.FixedZone("UTC+02:00", 2*60*60)).MarshalText()
+
+	var c Cron
+	if err := c.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := c.Location().String(); got != "UTC+02:00" {
+		t.Fatalf("expected UTC+02:00, got %q from %q", got, text)
+	}
+
+	// the zones built by the caller are written as their offset
+	for tz, want := range map[*time.Location]string{
+		time.FixedZone("X", 60*60):           "CRON_TZ=UTC+01:00 0 9 * * *",
+		time.FixedZone("", -(5*60+30)*60):    "CRON_TZ=UTC-05:30 0 9 * * *",
+		time.FixedZone("Europe/Madrid", 0):   "CRON_TZ=UTC 0 9 * * *",
+		time.FixedZone("Odd", 60*60+30):      "",
+		time.FixedZone("Far", (15*60+30)*60): "",
+	} {
+		text, err := MustParse("0 9 * * *", tz).MarshalText()
+		if want == "" {
+			if err != ErrUnsupportedZone {
+				t.Fatalf("%s: expected ErrUnsupportedZone, got %q, %v", tz, text, err)
+			}
+
+			continue
+		}
+
+		if err != nil || string(text) != want {
+			t.Fatalf("%s: expected %q, got %q, %v", tz, want, text, err)
+		}
+
+		var c Cron
+		if err := c.UnmarshalText(text); err != nil {
+			t.Fatal(err)
+		}
+
+		_, got := time.Date(2024, 1, 1, 0, 0, 0, 0, c.Location()).Zone()
+		if _, offset := time.Date(2024, 1, 1, 0, 0, 0, 0, tz).Zone(); got != offset {
+			t.Fatalf("%q: expected offset %d, got %d", text, offset, got)
+		}
+	}
+}
+
+func TestParseErrorOffset(t *testing.T) {
+	cases := []struct {
+		expr   string
+		field  string
+		token  string
+		offset int
+	}{
+		{"0 9 * 13 *", "month", "13", 6},
+		{"  0 9,25 * * *", "hour", "25", 6},
+		{"CRON_TZ=UTC 61 * * * *", "minute", "61", 12},
+		{"CRON_TZ=Mars/Olympus 0 * * * *", "timezone", "CRON_TZ=Mars/Olympus", 0},
+		{"0 0 * * MON-FRIDAY", "day of week", "MON-FRIDAY", 8},
+	}
+
+	for _, c := range cases {
+		_, err := Parse(c.expr, time.UTC)
+
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("%q: expected a *ParseError, got %v", c.expr, err)
+		}
+
+		if parseErr.Field != c.field || parseErr.Token != c.token || parseErr.Offset != c.offset {
+			t.Errorf("%q: expected %s %q at %d, got %s %q at %d", c.expr, c.field, c.token, c.offset, parseErr.Field, parseErr.Token, parseErr.Offset)
+		}
+	}
+
+	_, err := ParseQuartz("0 0 12 ? * MON#6", time.UTC)
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Offset != 11 {
+		t.Fatalf("expected the error at 11, got %v", err)
+	}
+}
+
+func TestPrevAndAlign(t *testing.T) {
+	exprs := []string{
+		"59 23 1 * 1",
+		"*/7 1,13 * * *",
+		"0 0 L * *",
+		"0 0 29 2 *",
+		"0 9 1W,15W,31W * *",
+		"0 9 * * FRI#2,1#5",
+		"30 2 * * *",
+		"0 0 1 1 * 2024,2026",
+	}
+
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	if err != nil {
+		madrid = time.UTC
+	}
+
+	for _, expr := range exprs {
+		c := MustParse(expr, madrid)
+
+		prev, err := c.Next(time.Date(2023, 12, 25, 0, 0, 0, 0, madrid))
+		if err != nil {
+			t.Fatalf("%q: %v", expr, err)
+		}
+
+		for i := 0; i < 50; i++ {
+			next, err := c.Next(prev)
+			if err != nil {
+				break
+			}
+
+			if got, err := c.Prev(next); err != nil || !got.Equal(prev) {
+				t.Fatalf("%q: expected %v before %v, got %v, %v", expr, prev, next, got, err)
+			}
+
+			mid := prev.Add(next.Sub(prev) / 2)
+			if got, err := c.Align(mid); err != nil || !got.Equal(prev) {
+				t.Fatalf("%q: expected %v to align to %v, got %v, %v", expr, mid, prev, got, err)
+			}
+
+			if !c.Matches(prev) || c.Matches(mid) {
+				t.Fatalf("%q: expected %v to match and %v not to match", expr, prev, mid)
+			}
+
+			prev = next
+		}
+	}
+
+	c := MustParse("0 0 1 1 * 2024", time.UTC)
+	if _, err := c.Prev(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)); err != ErrMaxYearLimit {
+		t.Fatalf("expected ErrMaxYearLimit, got %v", err)
+	}
+
+	s, _ := ParseWithOptions("*/15 * * * * *", WithSeconds())
+	if got, _ := s.Align(time.Date(2024, 5, 5, 10, 0, 44, 500, time.UTC)); !got.Equal(time.Date(2024, 5, 5, 10, 0, 30, 0, time.UTC)) {
+		t.Fatalf("unexpected alignment %v", got)
+	}
+}
+
+func TestLastDayOffset(t *testing.T) {
+	c := MustParse("0 18 L-2 * *", time.UTC)
+
+	next, _ := c.Next(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	if want := time.Date(2024, 2, 27, 18, 0, 0, 0, time.UTC); !next.Equal(want) {
+		t.Fatalf("expected %v, got %v", want, next)
+	}
+
+	if got := c.String(); got != "0 18 L-2 * *" {
+		t.Fatalf("unexpected expression %q", got)
+	}
+
+	for _, expr := range []string{"0 0 L-31 * *", "0 0 L-x * *", "0 0 L- * *"} {
+		if _, err := Parse(expr, time.UTC); !errors.Is(err, ErrInvalidExpression) {
+			t.Fatalf("%q: expected ErrInvalidExpression, got %v", expr, err)
+		}
+	}
+}
+
+func TestPhase(t *testing.T) {
+	c := MustParse("0 */6 * * *", time.UTC)
+
+	p, err := c.Phase(time.Date(2024, 3, 1, 7, 30, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.Elapsed != 90*time.Minute || p.Remaining != 270*time.Minute || p.Fraction != 0.25 {
+		t.Fatalf("unexpected phase %+v", p)
+	}
+
+	if p, _ := c.Phase(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)); p.Fraction != 0 || !p.Next.Equal(time.Date(2024, 3, 1, 18, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected an occurrence to start its interval, got %+v", p)
+	}
+}
+
+func TestPeriodFor(t *testing.T) {
+	c := MustParse("0 0 1 * *", time.UTC)
+
+	cases := []struct {
+		t, start, end time.Time
+	}{
+		{time.Date(2024, 2, 14, 9, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		// an occurrence starts its period
+		{time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+		// and the nanosecond before it is in the previous one
+		{time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range cases {
+		p, err := c.PeriodFor(tc.t)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !p.Start.Equal(tc.start) || !p.End.Equal(tc.end) || !p.Contains(tc.t) {
+			t.Fatalf("%v: expected [%v, %v), got %+v", tc.t, tc.start, tc.end, p)
+		}
+	}
+
+	if p, err := c.CurrentPeriod(); err != nil || !p.Contains(time.Now()) || p.Duration() < 28*24*time.Hour {
+		t.Fatalf("unexpected current period %+v (%v)", p, err)
+	}
+}
+
+func TestLastWeekday(t *testing.T) {
+	c := MustParse("0 17 LW * *", time.UTC)
+
+	// august 2024 ends on a saturday and march 2024 on a sunday
+	for _, want := range []time.Time{time.Date(2024, 8, 30, 17, 0, 0, 0, time.UTC), time.Date(2024, 3, 29, 17, 0, 0, 0, time.UTC)} {
+		next, _ := c.Next(want.AddDate(0, 0, -5))
+		if !next.Equal(want) {
+			t.Fatalf("expected %v, got %v", want, next)
+		}
+	}
+
+	if got := c.String(); got != "0 17 LW * *" {
+		t.Fatalf("unexpected expression %q", got)
+	}
+}
+
+func TestDynamicSchedule(t *testing.T) {
+	expr := "0 * * * *"
+	calls := 0
+
+	d := NewDynamicSchedule(func() (Schedule, error) {
+		calls++
+		return Parse(expr, time.UTC)
+	}, 0, 0)
+
+	from := time.Date(2024, 1, 1, 10, 20, 0, 0, time.UTC)
+
+	if next, _ := d.Next(from); !next.Equal(time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected occurrence %v", next)
+	}
+
+	expr = "*/5 * * * *"
+	if next, _ := d.Next(from); !next.Equal(time.Date(2024, 1, 1, 10, 25, 0, 0, time.UTC)) {
+		t.Fatalf("expected the new cadence, got %v", next)
+	}
+
+	// a broken cadence keeps the last schedule
+	expr = "*/0 * * * *"
+	if next, err := d.Next(from); err != nil || !next.Equal(time.Date(2024, 1, 1, 10, 25, 0, 0, time.UTC)) {
+		t.Fatalf("expected the last schedule, got %v, %v", next, err)
+	}
+
+	cached := NewDynamicSchedule(func() (Schedule, error) {
+		calls++
+		return Parse("* * * * *", time.UTC)
+	}, time.Hour, 10*time.Minute)
+
+	calls = 0
+	cached.Next(from)
+	next, _ := cached.Next(from)
+
+	if calls != 1 {
+		t.Fatalf("expected the schedule to be cached, got %d calls", calls)
+	}
+
+	if !next.Equal(from.Add(10 * time.Minute)) {
+		t.Fatalf("expected the min interval to be enforced, got %v", next)
+	}
+}
+
+func TestBackoffSchedule(t *testing.T) {
+	b := NewBackoffSchedule(MustParse("*/10 * * * *", time.UTC), 4)
+	from := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		err  error
+		want time.Duration
+	}{
+		{nil, 10 * time.Minute},
+		{errors.New("timeout"), 20 * time.Minute},
+		{errors.New("timeout"), 40 * time.Minute},
+		{errors.New("timeout"), 40 * time.Minute},
+		{nil, 10 * time.Minute},
+	}
+
+	for i, c := range cases {
+		b.Report(c.err)
+
+		if next, _ := b.Next(from); !next.Equal(from.Add(c.want)) {
+			t.Fatalf("%d: expected %v, got %v", i, from.Add(c.want), next)
+		}
+	}
+}
+
+func TestStepsInLists(t *testing.T) {
+	cases := map[string]string{
+		"1,15,30-50/5 * * * *": "1,15,30,35,40,45,50 * * * *",
+		"0 */8,12 * * *":       "0 0,8,12,16 * * *",
+		"0 0 * * MON/2":        "0 0 * * 1,3,5",
+		"0 0 * * */3":          "0 0 * * 0,3,6",
+		"0 0 * * 1-7/2":        "0 0 * * 0-1,3,5",
+	}
+
+	for expr, want := range cases {
+		if got := MustParse(expr, time.UTC).String(); got != want {
+			t.Errorf("%q: expected %q, got %q", expr, want, got)
+		}
+	}
+
+	for _, expr := range []string{"0 0 L/2 * *", "0 0 15W/2 * *", "0 0 * * MON#1/2", "0 0 * * 7/2"} {
+		if _, err := Parse(expr, time.UTC); !errors.Is(err, ErrInvalidExpression) {
+			t.Errorf("%q: expected ErrInvalidExpression, got %v", expr, err)
+		}
+	}
+}
+
+func TestBudgetSchedule(t *testing.T) {
+	var skipped []time.Time
+	b := NewBudgetSchedule(MustParse("*/10 * * * *", time.UTC), 2, time.Hour, func(t time.Time) { skipped = append(skipped, t) })
+
+	// previewing the occurrences doesn't use the budget
+	from := time.Date(2024, 1, 1, 9, 55, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		if next, _ := b.Next(from); !next.Equal(from.Add(5 * time.Minute)) {
+			t.Fatalf("expected %v, got %v", from.Add(5*time.Minute), next)
+		}
+	}
+
+	var got []time.Time
+	for next := from; len(got) < 4; {
+		next, _ = b.Next(next)
+		b.Fired(next)
+		got = append(got, next)
+
+		// asking again, or reporting the same run again, doesn't use more budget
+		b.Fired(next)
+		if again, _ := b.Next(next.Add(-time.Second)); !again.Equal(next) {
+			t.Fatalf("expected %v again, got %v", next, again)
+		}
+	}
+
+	want := []time.Time{
+		time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 10, 10, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 11, 10, 0, 0, time.UTC),
+	}
+
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+
+	if len(skipped) != 4 {
+		t.Fatalf("expected 4 skipped occurrences, got %v", skipped)
+	}
+}
+
+func TestParseMulti(t *testing.T) {
+	s := MustParseMulti([]string{"0 9 * * 1-5", "0 7 1 * *"}, time.UTC)
+
+	// saturday 1st of june 2024
+	next, _ := s.Next(time.Date(2024, 5, 31, 10, 0, 0, 0, time.UTC))
+	if want := time.Date(2024, 6, 1, 7, 0, 0, 0, time.UTC); !next.Equal(want) {
+		t.Fatalf("expected %v, got %v", want, next)
+	}
+
+	next, _ = s.Next(next)
+	if want := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC); !next.Equal(want) {
+		t.Fatalf("expected %v, got %v", want, next)
+	}
+
+	if _, ok := MustParseMulti([]string{"0 9 * * *"}, time.UTC).(*Cron); !ok {
+		t.Fatalf("expected a single expression to return a *Cron")
+	}
+
+	for _, exprs := range [][]string{nil, {"0 9 * * *", "0 25 * * *"}} {
+		if _, err := ParseMulti(exprs, time.UTC); !errors.Is(err, ErrInvalidExpression) {
+			t.Fatalf("%q: expected ErrInvalidExpression, got %v", exprs, err)
+		}
+	}
+}
+
+func TestSeasonalSchedule(t *testing.T) {
+	s := SeasonalSchedule{
+		Schedule: MustParse("0 7 * * *", time.UTC),
+		Offsets:  map[time.Month]time.Duration{time.June: -time.Hour, time.July: -2 * time.Hour},
+	}
+
+	cases := map[time.Time]time.Time{
+		time.Date(2024, 5, 31, 8, 0, 0, 0, time.UTC):  time.Date(2024, 6, 1, 6, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 30, 5, 30, 0, 0, time.UTC): time.Date(2024, 6, 30, 6, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 30, 6, 0, 0, 0, time.UTC):  time.Date(2024, 7, 1, 5, 0, 0, 0, time.UTC),
+		time.Date(2024, 7, 31, 6, 0, 0, 0, time.UTC):  time.Date(2024, 8, 1, 7, 0, 0, 0, time.UTC),
+	}
+
+	for from, want := range cases {
+		if got, err := s.Next(from); err != nil || !got.Equal(want) {
+			t.Errorf("%v: expected %v, got %v, %v", from, want, got, err)
+		}
+	}
+}
+
+func TestDualZone(t *testing.T) {
+	tokyo := time.FixedZone("UTC+09:00", 9*60*60)
+	london := time.FixedZone("UTC+00:00", 0)
+
+	c := MustParse("0 9 * * *", tokyo)
+	from := time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)
+
+	// 09:00 in Tokyo is 00:00 UTC, 09:00 in London is 09:00 UTC
+	both := c.DualZone(london, FireBoth)
+	if next, _ := both.Next(from); !next.Equal(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected occurrence %v", next)
+	}
+
+	earliest := c.DualZone(london, FireEarliest)
+	if next, _ := earliest.Next(from); !next.Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected london to be skipped, got %v", next)
+	}
+
+	if c.In(london).Location() != london || c.Location() != tokyo {
+		t.Fatalf("expected In to return a copy")
+	}
+}
+
+func TestParseSystemd(t *testing.T) {
+	cases := map[string]string{
+		"Mon..Fri *-*-* 06:00:00": "0 6 * * 1-5",
+		"*-*-01 04:30":            "30 4 1 * *",
+		"Sat,Sun 09:00":           "0 9 * * 0,6",
+		"*:0/15":                  "0,15,30,45 * * * *",
+		"hourly":                  "0 * * * *",
+		"quarterly":               "0 0 1 1,4,7,10 *",
+		"2025-03-05 08:05:40":     "40 5 8 5 3 * 2025",
+		"*-02~03 12:00":           "0 12 L-2 2 *",
+		"Wednesday 18:30:15":      "15 30 18 * * 3 *",
+		"01,07-01..03 00:00":      "0 0 1-3 1,7 *",
+	}
+
+	for expr, want := range cases {
+		c, err := ParseSystemd(expr, time.UTC)
+		if err != nil {
+			t.Fatalf("%q: %v", expr, err)
+		}
+
+		if got := c.String(); got != want {
+			t.Errorf("%q: expected %q, got %q", expr, want, got)
+		}
+	}
+
+	if c := MustParseSystemd("Mon 09:00 UTC", time.Local); c.Location().String() != "UTC" {
+		t.Fatalf("expected the timezone of the event, got %v", c.Location())
+	}
+
+	for _, expr := range []string{"", "Mon 25:00", "*-*-* 10:00:00.5", "Funday 10:00", "*-*-~40 10:00", "10:00 Mars/Olympus"} {
+		if _, err := ParseSystemd(expr, time.UTC); !errors.Is(err, ErrInvalidExpression) {
+			t.Errorf("%q: expected ErrInvalidExpression, got %v", expr, err)
+		}
+	}
+}
+
+func TestParseAWS(t *testing.T) {
+	s, err := ParseAWS("cron(0 10 ? * MON-FRI *)", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := s.(*Cron).String(); got != "0 10 * * 1-5" {
+		t.Fatalf("unexpected expression %q", got)
+	}
+
+	if got := MustParseAWS("cron(15 12 L * ? 2030)", time.UTC).(*Cron).String(); got != "15 12 L * * 2030" {
+		t.Fatalf("unexpected expression %q", got)
+	}
+
+	rate := MustParseAWS("rate(5 minutes)", time.UTC)
+	if next, _ := rate.Next(time.Date(2024, 1, 1, 10, 2, 30, 0, time.UTC)); !next.Equal(time.Date(2024, 1, 1, 10, 5, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected occurrence %v", next)
+	}
+
+	for _, expr := range []string{"cron(0 10 * * MON *)", "cron(0 10 ? * MON)", "rate(0 minutes)", "rate(5 weeks)", "0 10 * * *"} {
+		if _, err := ParseAWS(expr, time.UTC); !errors.Is(err, ErrInvalidExpression) {
+			t.Errorf("%q: expected ErrInvalidExpression, got %v", expr, err)
+		}
+	}
+
+	_, err = ParseAWS("cron(0 25 ? * MON *)", time.UTC)
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Offset != 7 {
+		t.Fatalf("expected the error at 7, got %v", err)
+	}
+}
+
+func TestFromRRule(t *testing.T) {
+	cases := map[string]string{
+		"FREQ=WEEKLY;BYDAY=MO,WE;BYHOUR=9;BYMINUTE=0":                          "0 9 * * 1,3",
+		"RRULE:FREQ=MONTHLY;BYDAY=-1FR;BYHOUR=17;BYMINUTE=30":                  "30 17 * * 5L",
+		"FREQ=MONTHLY;BYMONTHDAY=1,-1":                                         "0 0 1,L * *",
+		"FREQ=MINUTELY;INTERVAL=15":                                            "0,15,30,45 * * * *",
+		"DTSTART:20240105T083000Z\nRRULE:FREQ=WEEKLY":                          "30 8 * * 5",
+		"DTSTART:20240301T060000Z\nRRULE:FREQ=MONTHLY;INTERVAL=3":              "0 6 1 3,6,9,12 *",
+		"DTSTART:20240704T120000Z\nRRULE:FREQ=YEARLY":                          "0 12 4 7 *",
+		"FREQ=YEARLY;BYMONTH=11;BYDAY=4TH;BYHOUR=10":                           "0 10 * 11 4#4",
+		"DTSTART;TZID=Europe/Madrid:20240101T090000\nRRULE:FREQ=DAILY;WKST=MO": "0 9 * * *",
+	}
+
+	for rule, want := range cases {
+		c, err := FromRRule(rule, time.UTC)
+		if err != nil {
+			t.Fatalf("%q: %v", rule, err)
+		}
+
+		if got := c.String(); got != want {
+			t.Errorf("%q: expected %q, got %q", rule, want, got)
+		}
+	}
+
+	if c := MustFromRRule("DTSTART;TZID=Europe/Madrid:20240101T090000\nRRULE:FREQ=DAILY", time.UTC); c.Location().String() != "Europe/Madrid" {
+		t.Fatalf("expected the timezone of DTSTART, got %v", c.Location())
+	}
+
+	for _, rule := range []string{"FREQ=DAILY;COUNT=10", "FREQ=DAILY;INTERVAL=2", "FREQ=HOURLY;INTERVAL=5", "FREQ=MONTHLY;BYDAY=1MO;BYSETPOS=-1", "FREQ=WEEKLY;BYDAY=2MO"} {
+		if _, err := FromRRule(rule, time.UTC); err != ErrUnsupportedRule {
+			t.Errorf("%q: expected ErrUnsupportedRule, got %v", rule, err)
+		}
+	}
+
+	for _, rule := range []string{"", "FREQ=FORTNIGHTLY", "FREQ=DAILY;BYHOUR=24", "FREQ=WEEKLY", "FREQ=DAILY;INTERVAL=0", "FREQ=DAILY;FREQ=DAILY"} {
+		if _, err := FromRRule(rule, time.UTC); !errors.Is(err, ErrInvalidExpression) {
+			t.Errorf("%q: expected ErrInvalidExpression, got %v", rule, err)
+		}
+	}
+}
+
+func TestMonotonicSchedule(t *testing.T) {
+	expr := "0 * * * *"
+	d := NewDynamicSchedule(func() (Schedule, error) {
+		return Parse(expr, time.UTC)
+	}, 0, 0)
+
+	m := NewMonotonicSchedule(d, time.Time{}, AfterLast)
+
+	ref := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+	if next, _ := m.Next(ref); !next.Equal(time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected occurrence %v", next)
+	}
+
+	// the new cadence runs at 10:45, before the last occurrence returned, so it waits for the next day
+	expr = "45 10 * * *"
+	if next, _ := m.Next(ref); !next.Equal(time.Date(2024, 1, 2, 10, 45, 0, 0, time.UTC)) {
+		t.Fatalf("expected no occurrence before the last one, got %v", next)
+	}
+
+	if last := m.Last(); !last.Equal(time.Date(2024, 1, 2, 10, 45, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected last occurrence %v", last)
+	}
+
+	// an occurrence already run doesn't run again when the reference time moves back
+	last := ref.Add(30 * time.Minute)
+	if next, _ := NewMonotonicSchedule(MustParse("0 * * * *", time.UTC), last, AfterLast).Next(ref); !next.Equal(last.Add(time.Hour)) {
+		t.Fatalf("expected the occurrence after the last one, got %v", next)
+	}
+
+	if next, _ := NewMonotonicSchedule(MustParse("0 * * * *", time.UTC), last, FromLast).Next(ref); !next.Equal(last) {
+		t.Fatalf("expected the last occurrence again, got %v", next)
+	}
+
+	for _, mode := range []MonotonicMode{AfterLast, FromLast} {
+		if next, _ := NewMonotonicSchedule(MustParse("0 * * * *", time.UTC), last, mode).Next(last); !next.Equal(last.Add(time.Hour)) {
+			t.Fatalf("mode %d: expected the occurrence after the last one, got %v", mode, next)
+		}
+	}
+}
+
+func TestRRule(t *testing.T) {
+	cases := map[string]string{
+		"0 9 * * 1,3":    "FREQ=WEEKLY;BYDAY=MO,WE;BYHOUR=9;BYMINUTE=0;BYSECOND=0",
+		"30 17 * * 5L":   "FREQ=MONTHLY;BYDAY=-1FR;BYHOUR=17;BYMINUTE=30;BYSECOND=0",
+		"0 0 1,L * *":    "FREQ=MONTHLY;BYMONTHDAY=1,-1;BYHOUR=0;BYMINUTE=0;BYSECOND=0",
+		"*/15 * * * *":   "FREQ=HOURLY;BYMINUTE=0,15,30,45;BYSECOND=0",
+		"0 12 4 7 *":     "FREQ=MONTHLY;BYMONTH=7;BYMONTHDAY=4;BYHOUR=12;BYMINUTE=0;BYSECOND=0",
+		"0 10 * 11 4#4":  "FREQ=MONTHLY;BYMONTH=11;BYDAY=4TH;BYHOUR=10;BYMINUTE=0;BYSECOND=0",
+		"0 8-17/3 * * *": "FREQ=DAILY;BYHOUR=8,11,14,17;BYMINUTE=0;BYSECOND=0",
+	}
+
+	for expr, want := range cases {
+		c := MustParse(expr, time.UTC)
+
+		got, losses := c.RRule()
+		if got != want || losses != nil {
+			t.Errorf("%q: expected %q, got %q %q", expr, want, got, losses)
+		}
+
+		// the rule converts back to the same schedule
+		if back, err := FromRRule(got, time.UTC); err != nil || back.String() != c.String() {
+			t.Errorf("%q: %q converts back to %v (%v)", expr, got, back, err)
+		}
+	}
+
+	if _, losses := MustParse("0 17 LW * * 2025", time.UTC).RRule(); len(losses) != 2 {
+		t.Fatalf("expected the nearest weekday and the years to be reported, got %q", losses)
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	c := MustParse("0 9 * * *", madrid)
+
+	// the updated rules of the zone (here, Madrid moved to the offset of Lisbon)
+	lisbon, _ := time.LoadLocation("Europe/Lisbon")
+	zones := ZoneProviderFunc(func(name string) (*time.Location, error) {
+		if name != "Europe/Madrid" {
+			return nil, ErrLocationNotAllowed
+		}
+
+		return lisbon, nil
+	})
+
+	r, err := c.Refresh(zones)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	next, _ := r.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	if want := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC); !next.Equal(want) {
+		t.Fatalf("expected %v, got %v", want, next)
+	}
+
+	if c.Location() != madrid {
+		t.Fatal("expected the schedule to be kept")
+	}
+
+	if r, err := MustParse("0 9 * * *", time.UTC).Refresh(zones); err != nil || r.Location() != time.UTC {
+		t.Fatalf("expected UTC to be kept, got %v (%v)", r, err)
+	}
+}
+
+func TestParseNatural(t *testing.T) {
+	cases := map[string]string{
+		"every 15 minutes on weekdays between 9am and 5pm":  "*/15 9-16 * * 1-5",
+		"every day at 9:30am":                               "30 9 * * *",
+		"at 9am and 5pm on mondays, wednesdays and fridays": "0 9,17 * * 1,3,5",
+		"every hour":                                        "0 * * * *",
+		"every 10 seconds":                                  "*/10 * * * * *",
+		"monthly on the 1st and 15th at noon":               "0 12 1,15 * *",
+		"on the last day of the month at 23:45":             "45 23 L * *",
+		"every second tuesday at 18:00":                     "0 18 * * 2#2",
+		"on the last friday at 5 pm in march and september": "0 17 * 3,9 5L",
+		"every week":                                        "0 0 * * 0",
+		"every 2 hours between 10pm and 6am":                "0 0,2,4,22 * * *",
+		"daily at midnight":                                 "0 0 * * *",
+		"hourly between 9am and 5pm on weekdays":            "0 9-16 * * 1-5",
+		"between 8am and 8pm every 4 hours":                 "0 8,12,16 * * *",
+	}
+
+	for expr, want := range cases {
+		c, err := ParseNatural(expr, time.UTC)
+		if err != nil {
+			t.Fatalf("%q: %v", expr, err)
+		}
+
+		p := NewParser()
+		if len(splitFields(want, 0).fields) == 6 {
+			p = NewParser(WithSeconds())
+		}
+
+		w, err := p.Parse(want)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := c.String(); got != w.String() {
+			t.Errorf("%q: expected %q, got %q", expr, w.String(), got)
+		}
+	}
+
+	for _, expr := range []string{"", "every 3 days", "at 25:00", "at 9am and 5:30pm", "on funday", "every fortnight", "between 9am", "at 13pm", "every 90 minutes", "every 25 hours", "every 7 minutes", "every 45 seconds", "every 15 minutes between 9:30am and 5pm", "hourly between 9am and 5:30pm", "at 9am at 5pm", "hourly at 9am", "every 15 minutes at 9am", "on mondays on fridays", "yearly in march", "between 9am and 5pm at noon"} {
+		if _, err := ParseNatural(expr, time.UTC); !errors.Is(err, ErrInvalidExpression) {
+			t.Errorf("%q: expected ErrInvalidExpression, got %v", expr, err)
+		}
+	}
+}
+
+func TestDSTChanges(t *testing.T) {
+	cases := []struct {
+		expr, zone, from string
+		want             []string
+	}{
+		// the skipped 02:30 runs at 03:30
+		{"30 2 * * *", "America/New_York", "2024-03-10T00:00:00-05:00", []string{"2024-03-10T03:30:00-04:00", "2024-03-11T02:30:00-04:00"}},
+		// the repeated 01:30 runs twice
+		{"30 1 * * *", "America/New_York", "2024-11-03T00:00:00-04:00", []string{"2024-11-03T01:30:00-04:00", "2024-11-03T01:30:00-05:00", "2024-11-04T01:30:00-05:00"}},
+		// midnight is skipped
+		{"0 0 * * *", "America/Santiago", "2024-09-07T12:00:00-04:00", []string{"2024-09-08T01:00:00-03:00", "2024-09-09T00:00:00-03:00"}},
+		// a change of half an hour
+		{"0 * * * *", "Australia/Lord_Howe", "2024-10-06T01:00:00+10:30", []string{"2024-10-06T02:30:00+11:00", "2024-10-06T03:00:00+11:00"}},
+	}
+
+	for _, c := range cases {
+		tz, err := time.LoadLocation(c.zone)
+		if err != nil {
+			t.Skip(err)
+		}
+
+		s := MustParse(c.expr, tz)
+
+		from, _ := time.Parse(time.RFC3339, c.from)
+		next := from
+
+		for _, w := range c.want {
+			want, _ := time.Parse(time.RFC3339, w)
+
+			prev := next
+			if next, err = s.Next(prev); err != nil || !next.Equal(want) {
+				t.Fatalf("%q in %s: expected %v after %v, got %v, %v", c.expr, c.zone, want, prev, next, err)
+			}
+
+			if !s.Matches(next) {
+				t.Fatalf("%q in %s: expected %v to match", c.expr, c.zone, next)
+			}
+
+			if p, err := s.Prev(next.Add(time.Minute)); err != nil || !p.Equal(want) {
+				t.Fatalf("%q in %s: expected %v before %v, got %v, %v", c.expr, c.zone, want, next.Add(time.Minute), p, err)
+			}
+		}
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	cases := map[string]string{
+		"* * * * *":           "Every minute",
+		"*/5 * * * *":         "Every 5 minutes",
+		"0 */2 * * *":         "Every 2 hours",
+		"30 9,17 * * *":       "At 09:30 and 17:30",
+		"59 23 1 * 1":         "At 23:59 on the 1st of every month, Mondays only",
+		"*/15 9-17 * * 1-5":   "Every 15 minutes, between 09:00 and 17:59, Monday through Friday",
+		"0 9 * * 1,3":         "At 09:00, only on Mondays and Wednesdays",
+		"0 0 L-1 2 *":         "At 00:00 on the 2nd to last day of February",
+		"0 9 * * 5#2,1L":      "At 09:00 on the 2nd Friday and the last Monday of every month",
+		"0 12 * 6-8 *":        "At 12:00, only in June through August",
+		"0 0 1 1 * 2026-2028": "At 00:00 on the 1st of January, in 2026 through 2028",
+	}
+
+	for expr, want := range cases {
+		if got := MustParse(expr, time.UTC).Describe("en"); got != want {
+			t.Errorf("%q: expected %q, got %q", expr, want, got)
+		}
+	}
+
+	c, err := NewParser(WithSeconds()).Parse("15 30 9 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := c.Describe("en"); got != "At 09:30:15" {
+		t.Fatalf("expected the seconds in the time, got %q", got)
+	}
+}
+
+func TestDescribeLanguages(t *testing.T) {
+	c := MustParse("59 23 1 * 1", time.UTC)
+
+	cases := map[string]string{
+		"es":    "A las 23:59 el día 1 de cada mes, solo los lunes",
+		"de":    "Um 23:59 am 1. jedes Monats, nur montags",
+		"fr":    "À 23:59 le 1er de chaque mois, uniquement les lundis",
+		"pt-BR": "Às 23:59 no dia 1 de cada mês, apenas segundas-feiras",
+		"ja":    "At 23:59 on the 1st of every month, Mondays only",
+	}
+
+	for lang, want := range cases {
+		if got := c.Describe(lang); got != want {
+			t.Errorf("%s: expected %q, got %q", lang, want, got)
+		}
+	}
+
+	if got := MustParse("0 9 * * 5#2,6L", time.UTC).Describe("pt"); got != "Às 09:00 na 2ª sexta-feira e no último sábado de cada mês" {
+		t.Errorf("expected the gender of the weekdays, got %q", got)
+	}
+
+	// a catalog can be added for another language
+	nl := *Catalogs["en"]
+	nl.AtTimes, nl.OnWeekdays, nl.PluralWeekdays[1] = "om %s", "alleen op %s", "maandag"
+	Catalogs["nl"] = &nl
+	defer delete(Catalogs, "nl")
+
+	if got := MustParse("0 9 * * 1", time.UTC).Describe("nl"); got != "Om 09:00, alleen op maandag" {
+		t.Fatalf("expected the added catalog, got %q", got)
+	}
+}
+
+func TestFastPath(t *testing.T) {
+	cases := map[string]fastKind{
+		"* * * * *":       fastMinutes,
+		"*/15 * * * *":    fastMinutes,
+		"0,30 * * * *":    fastMinutes,
+		"30 9 * * *":      fastDaily,
+		"*/7 * * * *":     fastNone,
+		"30 9 * * 1-5":    fastNone,
+		"30 9 * * * 2025": fastNone,
+		"0 9,17 * * *":    fastNone,
+	}
+
+	for expr, kind := range cases {
+		if got := MustParse(expr, time.UTC).fast.kind; got != kind {
+			t.Errorf("%q: expected the fast path %d, got %d", expr, kind, got)
+		}
+	}
+
+	// the fast path returns the same occurrences as the generic search, also around the changes of DST
+	var zones []*time.Location
+	for _, name := range []string{"America/New_York", "Australia/Lord_Howe", "America/Santiago", "Asia/Kathmandu"} {
+		if loc, err := time.LoadLocation(name); err == nil {
+			zones = append(zones, loc)
+		}
+	}
+
+	r := rand.New(rand.NewSource(1))
+
+	for _, tz := range append(zones, time.UTC) {
+		for _, expr := range []string{"* * * * *", "*/20 * * * *", "30 2 * * *", "0 0 * * *", "15 1 * * *"} {
+			c := MustParse(expr, tz)
+
+			generic := *c
+			generic.fast = fastPath{}
+
+			for i := 0; i < 2000; i++ {
+				from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(r.Int63n(int64(366 * 24 * time.Hour))))
+
+				want, _ := generic.Next(from)
+				if got, err := c.Next(from); err != nil || !got.Equal(want) {
+					t.Fatalf("%q in %s from %v: expected %v, got %v, %v", expr, tz, from.In(tz), want, got, err)
+				}
+			}
+		}
+	}
+}
+
+func TestNCrontab(t *testing.T) {
+	p := NewParser(WithNCrontab())
+
+	cases := map[string]string{
+		"*/15 9-17 * * MON-FRI": "0,15,30,45 9-17 * * 1-5",
+		"30 0 12 1 JAN,JUL 0":   "30 0 12 1 1,7 0 *",
+		"0 0 * * 6":             "0 0 * * 6",
+	}
+
+	for expr, want := range cases {
+		c, err := p.Parse(expr)
+		if err != nil {
+			t.Fatalf("%q: %v", expr, err)
+		}
+
+		if got := c.String(); got != want {
+			t.Errorf("%q: expected %q, got %q", expr, want, got)
+		}
+	}
+
+	// the days of month and of week must both match: the 13th when it is a friday
+	next, _ := MustParse("0 0 13 * 5", time.UTC).Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	if got, _ := p.Parse("0 0 13 * 5"); !got.Matches(next) || next.Day() != 13 || next.Weekday() != time.Friday {
+		t.Fatalf("unexpected occurrence %v", next)
+	}
+
+	for _, expr := range []string{"0 0 * * 7", "@daily", "0 0 1 1 * 2030 *", "0 0 *"} {
+		if _, err := p.Parse(expr); !errors.Is(err, ErrInvalidExpression) {
+			t.Errorf("%q: expected ErrInvalidExpression, got %v", expr, err)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// returns a description of the schedule in the language, like "At 23:59 on the 1st of every month, Mondays only", for dashboards and UIs
+//
+// the language is a key of Catalogs (e.g. "es"), or a tag whose base language is one ("pt-BR"); other languages are described in English. The times of day are written in 24-hour format, and the days of month and of week are joined with "only" since both must match. The timezone is not described
+func (s *Cron) Describe(lang string) string {
+	c := catalogFor(lang)
+
+	desc := s.describeTime(c)
+
+	if days, onDays := s.describeDays(c); days != "" {
+		if onDays {
+			desc += " " + days
+		} else {
+			desc += ", " + days
+		}
+	}
+
+	if s.year != nil {
+		desc += ", " + fmt.Sprintf(c.InYears, c.list(s.year, strconv.Itoa))
+	}
+
+	r, size := utf8.DecodeRuneInString(desc)
+
+	return string(unicode.ToUpper(r)) + desc[size:]
+}
+
+// returns the description of the seconds, minutes and hours
+func (s *Cron) describeTime(c *Catalog) string {
+	seconds := bitsetValues[bitset64, int](s.second, boundSecond)
+	minutes := bitsetValues[bitset64, int](s.minute, boundMinute)
+	hours := bitsetValues[bitset32, int](s.hour, boundHour)
+
+	allSeconds := s.second == buildBitset[bitset64](boundSecond.min, boundSecond.max, 1)
+	allMinutes := s.minute == buildBitset[bitset64](boundMinute.min, boundMinute.max, 1)
+	allHours := s.hour == buildBitset[bitset32](boundHour.min, boundHour.max, 1)
+
+	// a few times of day are listed, e.g. "at 09:30 and 17:30"
+	if len(seconds) == 1 && len(minutes) == 1 && !allHours && len(hours) <= 4 {
+		times := make([]string, len(hours))
+		for i, h := range hours {
+			times[i] = describeClock(h, minutes[0], seconds[0])
+		}
+
+		return fmt.Sprintf(c.AtTimes, c.join(times))
+	}
+
+	var phrases []string
+
+	switch step, ok := valuesStep(seconds, boundSecond); {
+	case allSeconds:
+		phrases = append(phrases, c.EverySecond)
+	case len(seconds) == 1 && seconds[0] == 0:
+	case ok:
+		phrases = append(phrases, fmt.Sprintf(c.EverySeconds, step))
+	case len(seconds) == 1:
+		phrases = append(phrases, fmt.Sprintf(c.AtSecond, seconds[0]))
+	default:
+		phrases = append(phrases, fmt.Sprintf(c.AtSeconds, c.list(seconds, strconv.Itoa)))
+	}
+
+	hourStep, hourStepOK := valuesStep(hours, boundHour)
+
+	switch step, ok := valuesStep(minutes, boundMinute); {
+	case allMinutes:
+		// every second is already every minute
+		if len(phrases) == 0 {
+			phrases = append(phrases, c.EveryMinute)
+		}
+	case ok:
+		phrases = append(phrases, fmt.Sprintf(c.EveryMinutes, step))
+	case len(minutes) == 1 && minutes[0] == 0 && len(phrases) == 0:
+		if !hourStepOK {
+			phrases = append(phrases, c.EveryHour)
+		}
+	case len(minutes) == 1:
+		phrases = append(phrases, fmt.Sprintf(c.AtMinute, minutes[0]))
+	default:
+		phrases = append(phrases, fmt.Sprintf(c.AtMinutes, c.list(minutes, strconv.Itoa)))
+	}
+
+	switch {
+	case allHours:
+	case hourStepOK:
+		phrases = append(phrases, fmt.Sprintf(c.EveryHours, hourStep))
+	case hours[len(hours)-1]-hours[0] == len(hours)-1:
+		phrases = append(phrases, fmt.Sprintf(c.BetweenHours, describeClock(hours[0], 0, 0), describeClock(hours[len(hours)-1], 59, 0)))
+	default:
+		phrases = append(phrases, fmt.Sprintf(c.DuringHours, c.list(hours, strconv.Itoa)))
+	}
+
+	return strings.Join(phrases, ", ")
+}
+
+// returns the description of the days of month, the days of week and the months, or an empty string when the schedule runs every day, and whether it describes days of the month, which follow the time without a comma
+func (s *Cron) describeDays(c *Catalog) (string, bool) {
+	var months string
+	if s.month != buildBitset[bitset16](boundMonth.min, boundMonth.max, 1) {
+		months = c.list(bitsetValues[bitset16, int](s.month, boundMonth), func(m int) string {
+			return c.Months[m-1]
+		})
+	}
+
+	weekdays := s.describeWeekdays(c)
+
+	// the nth weekdays are days of the month too, e.g. "on the 2nd Friday of every month"
+	days := ""
+	switch {
+	case s.dom != buildBitset[bitset32](boundDOM.min, boundDOM.max, 1):
+		days = s.describeMonthDays(c)
+	case s.dowLast != 0 || s.dowNth != [7]bitset8{}:
+		days, weekdays = weekdays, ""
+	}
+
+	if days != "" {
+		desc := fmt.Sprintf(c.OnDays, days)
+		if months != "" {
+			desc = fmt.Sprintf(c.OnDaysIn, days, months)
+		}
+
+		if weekdays != "" {
+			desc += ", " + fmt.Sprintf(c.WeekdaysOnly, weekdays)
+		}
+
+		return desc, true
+	}
+
+	var phrases []string
+
+	if weekdays != "" {
+		// a single range of weekdays needs no "only", e.g. "Monday through Friday"
+		if runs := valueRuns(bitsetValues[bitset8, int](s.dow, boundDOW)); len(runs) == 1 && runs[0][1]-runs[0][0] >= 2 {
+			phrases = append(phrases, weekdays)
+		} else {
+			phrases = append(phrases, fmt.Sprintf(c.OnWeekdays, weekdays))
+		}
+	}
+
+	if months != "" {
+		phrases = append(phrases, fmt.Sprintf(c.InMonths, months))
+	}
+
+	return strings.Join(phrases, ", "), false
+}
+
+// returns the description of the days of month field, e.g. "the 1st, the 15th and the last day"
+func (s *Cron) describeMonthDays(c *Catalog) string {
+	var items []string
+
+	for _, run := range valueRuns(bitsetValues[bitset32, int](s.dom, boundDOM)) {
+		if run[1]-run[0] >= 2 {
+			items = append(items, c.DayRange(run[0], run[1]))
+			continue
+		}
+
+		for d := run[0]; d <= run[1]; d++ {
+			items = append(items, c.Day(d))
+		}
+	}
+
+	for d := boundDOM.min; d <= boundDOM.max; d++ {
+		if s.domWeekday&(1<<d) != 0 {
+			items = append(items, c.NearestWeekday(d))
+		}
+	}
+
+	if s.domWeekday&1 != 0 {
+		items = append(items, c.LastWeekday)
+	}
+
+	if s.domLast&1 != 0 {
+		items = append(items, c.LastDay)
+	}
+
+	for n := 1; n < boundDOM.max; n++ {
+		if s.domLast&(1<<n) != 0 {
+			items = append(items, c.DayBeforeLast(n))
+		}
+	}
+
+	return c.join(items)
+}
+
+// returns the description of the day of week field, e.g. "Mondays and the last Friday", or an empty string when it matches every day
+func (s *Cron) describeWeekdays(c *Catalog) string {
+	var items []string
+
+	if s.dow != 0 && s.dow != buildBitset[bitset8](boundDOW.min, boundDOW.max, 1) {
+		// the weekdays are plural, but not the ranges ("Mondays and Wednesday through Friday")
+		for _, run := range valueRuns(bitsetValues[bitset8, int](s.dow, boundDOW)) {
+			if run[1]-run[0] >= 2 {
+				items = append(items, fmt.Sprintf(c.Through, c.Weekdays[run[0]], c.Weekdays[run[1]]))
+				continue
+			}
+
+			for w := run[0]; w <= run[1]; w++ {
+				items = append(items, c.PluralWeekdays[w])
+			}
+		}
+	}
+
+	for w := boundDOW.min; w <= boundDOW.max; w++ {
+		for n := 1; n <= 5; n++ {
+			if s.dowNth[w]&(1<<n) != 0 {
+				items = append(items, c.NthWeekday(n, time.Weekday(w)))
+			}
+		}
+	}
+
+	for w := boundDOW.min; w <= boundDOW.max; w++ {
+		if s.dowLast&(1<<w) != 0 {
+			items = append(items, c.LastOfWeekday(time.Weekday(w)))
+		}
+	}
+
+	return c.join(items)
+}
+
+// returns the time of day as "15:04", or "15:04:05" when the seconds are not 0
+func describeClock(hour, minute, second int) string {
+	if second != 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", hour, minute, second)
+	}
+
+	return fmt.Sprintf("%02d:%02d", hour, minute)
+}
+
+// returns the step of the values when they are every step from the min of the bounds to the end (e.g. "*/15"), and whether they are
+func valuesStep(values []int, bounds fieldBounds) (int, bool) {
+	if len(values) < 3 || values[0] != bounds.min {
+		return 0, false
+	}
+
+	step := values[1] - values[0]
+	if step < 2 || values[len(values)-1]+step <= bounds.max {
+		return 0, false
+	}
+
+	for i := 2; i < len(values); i++ {
+		if values[i]-values[i-1] != step {
+			return 0, false
+		}
+	}
+
+	return step, true
+}
+
+// returns the first and last values of each run of consecutive values
+func valueRuns(values []int) [][2]int {
+	var runs [][2]int
+
+	for i := 0; i < len(values); i++ {
+		end := i
+		for end+1 < len(values) && values[end+1] == values[end]+1 {
+			end++
+		}
+
+		runs = append(runs, [2]int{values[i], values[end]})
+		i = end
+	}
+
+	return runs
+}
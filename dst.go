@@ -0,0 +1,132 @@
+package cron
+
+import (
+	"time"
+)
+
+// returns the first occurrence after t and before limit whose wall clock time was skipped by the clocks going forward, shifted by the length of the change, or false if there is none
+//
+// the skipped times are the ones of the offset before the change, so the shifted occurrences are the occurrences of the schedule in that offset within the length of the change
+func (s *Cron) nextSkipped(t, limit time.Time) (time.Time, bool) {
+	// t may be within the length of the last change
+	start := lastZoneChange(t.In(s.tz))
+
+	for {
+		change := zoneChange(start)
+		if change.IsZero() || !change.Before(limit) {
+			return time.Time{}, false
+		}
+
+		_, before := start.Zone()
+		_, after := change.Zone()
+
+		if gap := time.Duration(after-before) * time.Second; gap > 0 && s.skips(change, gap, before) {
+			ref := change.Add(-time.Nanosecond)
+			if ref.Before(t) {
+				ref = t
+			}
+
+			next, err := s.In(time.FixedZone("", before)).nextWallClock(ref)
+			if err == nil && next.Before(change.Add(gap)) && next.Before(limit) {
+				return next.In(s.tz), true
+			}
+		}
+
+		start = change
+	}
+}
+
+// returns the last occurrence before t and after limit whose wall clock time was skipped by the clocks going forward, shifted by the length of the change, or false if there is none
+func (s *Cron) prevSkipped(t, limit time.Time) (time.Time, bool) {
+	var (
+		prev  time.Time
+		found bool
+	)
+
+	start := lastZoneChange(limit.In(s.tz))
+
+	for {
+		change := zoneChange(start)
+		if change.IsZero() || !change.Before(t) {
+			return prev, found
+		}
+
+		_, before := start.Zone()
+		_, after := change.Zone()
+
+		if gap := time.Duration(after-before) * time.Second; gap > 0 && s.skips(change, gap, before) {
+			ref := change.Add(gap)
+			if ref.After(t) {
+				ref = t
+			}
+
+			p, err := s.In(time.FixedZone("", before)).prevWallClock(ref)
+			if err == nil && !p.Before(change) && p.After(limit) {
+				prev, found = p.In(s.tz), true
+			}
+		}
+
+		start = change
+	}
+}
+
+// reports whether any hour skipped by a change of length gap at change, from the offset before, matches the expression, so that the minutes and seconds are only searched for those changes
+func (s *Cron) skips(change time.Time, gap time.Duration, before int) bool {
+	fixed := time.FixedZone("", before)
+
+	// the start of the hour on the wall clock, which Truncate doesn't give for offsets of half an hour
+	h := change.In(fixed)
+	h = h.Add(-time.Duration(h.Minute())*time.Minute - time.Duration(h.Second())*time.Second - time.Duration(h.Nanosecond()))
+
+	for ; h.Before(change.Add(gap)); h = h.Add(time.Hour) {
+		if s.matchesHour(h) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reports whether t is the shifted occurrence of a wall clock time skipped by the clocks going forward
+func (s *Cron) matchesSkipped(t time.Time) bool {
+	change, _ := t.In(s.tz).ZoneBounds()
+	if change.IsZero() {
+		return false
+	}
+
+	_, before := change.Add(-time.Nanosecond).Zone()
+	_, after := change.Zone()
+
+	gap := time.Duration(after-before) * time.Second
+	if gap <= 0 || !t.Before(change.Add(gap)) {
+		return false
+	}
+
+	next, err := s.In(time.FixedZone("", before)).nextWallClock(t.Add(-time.Nanosecond))
+
+	return err == nil && next.Equal(t)
+}
+
+// returns the time of the first zone change after t, or the zero time if there is none
+//
+// past the last transition of the database, time.ZoneBounds may return the start of t as the end at the end of leap years, so the search skips a day then
+func zoneChange(t time.Time) time.Time {
+	for {
+		_, end := t.ZoneBounds()
+		if end.IsZero() || end.After(t) {
+			return end
+		}
+
+		t = t.Add(24 * time.Hour)
+	}
+}
+
+// returns a time just before the last zone change before t, or t if there is none
+func lastZoneChange(t time.Time) time.Time {
+	start, _ := t.ZoneBounds()
+	if start.IsZero() {
+		return t
+	}
+
+	return start.Add(-time.Nanosecond)
+}
@@ -0,0 +1,81 @@
+package cron
+
+import (
+	"time"
+)
+
+type (
+	// how a DualZoneSchedule combines the occurrences of its timezones
+	DualZoneMode int
+
+	// a schedule running the same expression in two timezones; e.g., follow the sun jobs
+	DualZoneSchedule struct {
+		first, second *Cron
+		mode          DualZoneMode
+	}
+)
+
+const (
+	// runs each occurrence only in the timezone where it comes first: 09:00 in Tokyo and 09:00 in London are a single occurrence, at 09:00 in Tokyo
+	FireEarliest DualZoneMode = iota
+	// runs the occurrences of both timezones
+	FireBoth
+)
+
+// returns a copy of the schedule in another timezone
+func (s *Cron) In(tz *time.Location) *Cron {
+	c := *s
+	c.tz = tz
+
+	return &c
+}
+
+// returns a schedule running the expression of the schedule both in its timezone and in tz, combined with the mode
+func (s *Cron) DualZone(tz *time.Location, mode DualZoneMode) DualZoneSchedule {
+	return DualZoneSchedule{first: s, second: s.In(tz), mode: mode}
+}
+
+// returns the first occurrence after t in any of the timezones
+//
+// with FireEarliest, an occurrence is skipped when the same wall clock time comes earlier in the other timezone, even if that was before t
+func (d DualZoneSchedule) Next(t time.Time) (time.Time, error) {
+	for {
+		next, other, err := d.earliest(t)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if d.mode == FireBoth {
+			return next, nil
+		}
+
+		// the same wall clock time in the other timezone
+		year, month, day := next.Date()
+		twin := time.Date(year, month, day, next.Hour(), next.Minute(), next.Second(), 0, other.tz)
+
+		if !twin.Before(next) || !other.Matches(twin) {
+			return next, nil
+		}
+
+		t = next
+	}
+}
+
+// returns the first occurrence after t of both timezones, with the schedule of the other timezone
+func (d DualZoneSchedule) earliest(t time.Time) (time.Time, *Cron, error) {
+	first, err := d.first.Next(t)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+
+	second, err := d.second.Next(t)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+
+	if second.Before(first) {
+		return second, d.first, nil
+	}
+
+	return first, d.second, nil
+}
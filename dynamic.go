@@ -0,0 +1,78 @@
+package cron
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+type (
+	// a schedule whose occurrences are the ones of the schedule returned by a callback, so the cadence can change (e.g. read from a feature flag) without replacing the schedule
+	DynamicSchedule struct {
+		resolve     func() (Schedule, error)
+		ttl         time.Duration
+		minInterval time.Duration
+
+		mu         sync.Mutex
+		schedule   Schedule
+		resolvedAt time.Time
+	}
+)
+
+var (
+	ErrNoSchedule = errors.New("the callback returned no schedule")
+)
+
+// returns a schedule that calls resolve to get the schedule whose occurrences it returns
+//
+// the schedule returned by resolve is reused for ttl before calling it again; when resolve fails, the last schedule it returned is kept. The occurrences are at least minInterval after the reference time of Next, so a wrong cadence can't run the job too often. Zero values disable the cache and the min interval
+func NewDynamicSchedule(resolve func() (Schedule, error), ttl, minInterval time.Duration) *DynamicSchedule {
+	return &DynamicSchedule{
+		resolve:     resolve,
+		ttl:         ttl,
+		minInterval: minInterval,
+	}
+}
+
+// returns the first occurrence of the current schedule at least the min interval after t
+//
+// it returns the error of the callback, or ErrNoSchedule if it returned nil, only when there is no previous schedule to keep
+func (d *DynamicSchedule) Next(t time.Time) (time.Time, error) {
+	s, err := d.current()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if d.minInterval > 0 {
+		return nextFrom(s, t.Add(d.minInterval))
+	}
+
+	return s.Next(t)
+}
+
+// returns the cached schedule, or the one returned by the callback when the cache expired
+func (d *DynamicSchedule) current() (Schedule, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.schedule != nil && d.ttl > 0 && time.Since(d.resolvedAt) < d.ttl {
+		return d.schedule, nil
+	}
+
+	s, err := d.resolve()
+	if err == nil && s == nil {
+		err = ErrNoSchedule
+	}
+
+	if err != nil {
+		if d.schedule != nil {
+			return d.schedule, nil
+		}
+
+		return nil, err
+	}
+
+	d.schedule, d.resolvedAt = s, time.Now()
+
+	return s, nil
+}
@@ -0,0 +1,156 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type (
+	// describes why an expression could not be parsed
+	//
+	// it wraps ErrInvalidExpression, so errors.Is(err, ErrInvalidExpression) keeps working
+	ParseError struct {
+		// the name of the field that failed ("minute", "hour", ...), empty when the error is not related to a single field
+		Field string
+		// the offending part of the expression
+		Token string
+		// the byte offset of Token in the expression
+		Offset int
+		// what is wrong with the token
+		Reason string
+		// a hint to fix the token (e.g. "did you mean 0?"), empty if there is none
+		Suggestion string
+	}
+
+	// the fields of an expression and where they are, to locate the tokens of the parse errors
+	exprFields struct {
+		fields []string
+		// the byte offset of each field in the expression
+		offsets []int
+		// the index in layoutFields of each field
+		indexes []int
+	}
+)
+
+func (e *ParseError) Error() string {
+	var b strings.Builder
+
+	b.WriteString(ErrInvalidExpression.Error())
+	b.WriteString(": ")
+
+	if e.Field != "" {
+		b.WriteString(e.Field)
+		b.WriteString(" field: ")
+	}
+
+	b.WriteString(e.Reason)
+
+	if e.Suggestion != "" {
+		b.WriteString(" (")
+		b.WriteString(e.Suggestion)
+		b.WriteString(")")
+	}
+
+	return b.String()
+}
+
+func (e *ParseError) Unwrap() error {
+	return ErrInvalidExpression
+}
+
+// returns the error for a value outside the bounds of the field, suggesting the closest valid value
+//
+// a value right above the max of a field starting at 0 is suggested to wrap around (e.g. minute 60 => 0)
+func (b fieldBounds) outOfRangeError(token string, v int) error {
+	var suggestion int
+
+	switch {
+	case v == b.max+1 && b.min == 0:
+		suggestion = b.min
+	case v > b.max:
+		suggestion = b.max
+	default:
+		suggestion = b.min
+	}
+
+	return &ParseError{
+		Field:      b.name,
+		Token:      token,
+		Reason:     fmt.Sprintf("'%d' is out of range %d-%d", v, b.min, b.max),
+		Suggestion: fmt.Sprintf("did you mean %d?", suggestion),
+	}
+}
+
+// returns the error for a token of the field that is not valid
+func (b fieldBounds) syntaxError(token, reason, suggestion string) error {
+	return &ParseError{
+		Field:      b.name,
+		Token:      token,
+		Reason:     reason,
+		Suggestion: suggestion,
+	}
+}
+
+// returns the error for a value of the field that is neither a number nor a name, suggesting the name it starts with (e.g. "MONDAY" => "MON")
+func (b fieldBounds) notANumberError(token, v string) error {
+	suggestion := ""
+	for _, alias := range b.aliases {
+		if len(v) > len(alias) && strings.EqualFold(v[:len(alias)], alias) {
+			suggestion = fmt.Sprintf("use %s", alias)
+			break
+		}
+	}
+
+	return &ParseError{
+		Field:      b.name,
+		Token:      token,
+		Reason:     fmt.Sprintf("'%s' is not a valid value", v),
+		Suggestion: suggestion,
+	}
+}
+
+// returns the fields of the expression at the given byte offset, split around white space as strings.Fields does
+func splitFields(expr string, offset int) exprFields {
+	var f exprFields
+
+	start := -1
+	for i, r := range expr + " " {
+		switch {
+		case unicode.IsSpace(r) && start >= 0:
+			f.fields = append(f.fields, expr[start:i])
+			f.offsets = append(f.offsets, offset+start)
+			start = -1
+		case !unicode.IsSpace(r) && start < 0:
+			start = i
+		}
+	}
+
+	return f
+}
+
+// sets the offset of the token of a *ParseError of one of the fields, and returns the error
+//
+// the offset is the one of the whole field when the token is not in it (e.g. a value picked for a random range)
+func (f exprFields) locate(err error) error {
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		return err
+	}
+
+	for i, j := range f.indexes {
+		if randomBounds[j].name != parseErr.Field {
+			continue
+		}
+
+		parseErr.Offset = f.offsets[i]
+		if k := strings.Index(f.fields[i], parseErr.Token); k >= 0 {
+			parseErr.Offset += k
+		}
+
+		break
+	}
+
+	return err
+}
@@ -0,0 +1,112 @@
+package cron
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+type (
+	// a flat record of a single occurrence of a schedule
+	Occurrence struct {
+		// the id given to the schedule
+		ID string
+		// the occurrence in UTC
+		Time time.Time
+		// the name of the timezone of the schedule
+		Location string
+		// the occurrence in the timezone of the schedule
+		Local time.Time
+	}
+)
+
+const (
+	localTimeLayout = "2006-01-02T15:04:05"
+)
+
+// calls fn for each occurrence of the schedules within [from, to), ordered by schedule id and then by time
+//
+// it stops and returns the error returned by fn, if any
+func ExportOccurrences(schedules map[string]*Cron, from, to time.Time, fn func(Occurrence) error) error {
+	ids := make([]string, 0, len(schedules))
+	for id := range schedules {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		s := schedules[id]
+
+		next, err := nextFrom(s, from)
+		for ; !errors.Is(err, ErrMaxYearLimit); next, err = s.Next(next) {
+			if err != nil {
+				return err
+			}
+
+			if !next.Before(to) {
+				break
+			}
+
+			err = fn(Occurrence{
+				ID:       id,
+				Time:     next.UTC(),
+				Location: s.tz.String(),
+				Local:    next,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// writes the occurrences of the schedules within [from, to) as CSV rows to w, with a header row
+//
+// the columns are id, time (RFC 3339, UTC), tz and local (the wall clock time in tz, without offset)
+func WriteOccurrencesCSV(w io.Writer, schedules map[string]*Cron, from, to time.Time) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"id", "time", "tz", "local"}); err != nil {
+		return err
+	}
+
+	err := ExportOccurrences(schedules, from, to, func(o Occurrence) error {
+		return cw.Write([]string{o.ID, o.Time.Format(time.RFC3339), o.Location, o.Local.Format(localTimeLayout)})
+	})
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// writes the occurrences of the schedules within [from, to) to w as one INSERT statement per occurrence into the given table
+//
+// the columns are id, scheduled_at (RFC 3339, UTC), tz and local_time; the table name may be schema qualified (e.g. "jobs.queue")
+func WriteOccurrencesSQL(w io.Writer, table string, schedules map[string]*Cron, from, to time.Time) error {
+	parts := strings.Split(table, ".")
+	for i, part := range parts {
+		parts[i] = `"` + strings.ReplaceAll(part, `"`, `""`) + `"`
+	}
+
+	prefix := "INSERT INTO " + strings.Join(parts, ".") + " (id, scheduled_at, tz, local_time) VALUES ("
+
+	return ExportOccurrences(schedules, from, to, func(o Occurrence) error {
+		_, err := fmt.Fprintf(w, "%s%s, %s, %s, %s);\n", prefix, quoteSQL(o.ID), quoteSQL(o.Time.Format(time.RFC3339)), quoteSQL(o.Location), quoteSQL(o.Local.Format(localTimeLayout)))
+		return err
+	})
+}
+
+// returns the value as a SQL string literal
+func quoteSQL(v string) string {
+	return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+}
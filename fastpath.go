@@ -0,0 +1,106 @@
+package cron
+
+import (
+	"math/bits"
+	"time"
+)
+
+type (
+	// the kinds of the expressions with a specialized Next
+	fastKind uint8
+
+	// the specialized Next of a common expression, found when the schedule is built
+	fastPath struct {
+		kind fastKind
+		// the minutes between the occurrences of fastMinutes
+		step int
+		// the time of day of fastDaily, and the second of both kinds
+		hour, minute, second int
+	}
+)
+
+const (
+	// the schedule runs through the generic search
+	fastNone fastKind = iota
+	// every n minutes of every hour and day, with n dividing an hour (e.g. "* * * * *" or "*/15 * * * *")
+	fastMinutes
+	// a single time of every day (e.g. "30 9 * * *")
+	fastDaily
+)
+
+// returns the fast path of the schedule, whose kind is fastNone when it has none
+func (s *Cron) fastPath() fastPath {
+	everyDay := s.dom == buildBitset[bitset32](boundDOM.min, boundDOM.max, 1) && s.domLast == 0 && s.domWeekday == 0 &&
+		s.dow == buildBitset[bitset8](boundDOW.min, boundDOW.max, 1) && s.dowLast == 0 && s.dowNth == [7]bitset8{} &&
+		s.month == buildBitset[bitset16](boundMonth.min, boundMonth.max, 1) && s.year == nil
+
+	if !everyDay || bits.OnesCount64(uint64(s.second)) != 1 {
+		return fastPath{}
+	}
+
+	second := bits.TrailingZeros64(uint64(s.second))
+
+	if s.hour == buildBitset[bitset32](boundHour.min, boundHour.max, 1) {
+		for step := 1; step <= 30; step++ {
+			if 60%step == 0 && s.minute == buildBitset[bitset64](boundMinute.min, boundMinute.max, step) {
+				return fastPath{kind: fastMinutes, step: step, second: second}
+			}
+		}
+	}
+
+	if bits.OnesCount32(uint32(s.hour)) == 1 && bits.OnesCount64(uint64(s.minute)) == 1 {
+		return fastPath{
+			kind:   fastDaily,
+			hour:   bits.TrailingZeros32(uint32(s.hour)),
+			minute: bits.TrailingZeros64(uint64(s.minute)),
+			second: second,
+		}
+	}
+
+	return fastPath{}
+}
+
+// returns the next occurrence after t computed with the fast path of the schedule, or false when it has none or the result could be wrong
+//
+// the arithmetic is only right within a single offset, so t must be a day away from the last zone change, whose skipped times may run shifted, and the result before the next one
+func (s *Cron) nextFast(t time.Time) (time.Time, bool) {
+	if s.fast.kind == fastNone {
+		return time.Time{}, false
+	}
+
+	t = t.In(s.tz)
+
+	start, end := t.ZoneBounds()
+	if !start.IsZero() && t.Sub(start) < 24*time.Hour {
+		return time.Time{}, false
+	}
+
+	var next time.Time
+
+	switch s.fast.kind {
+	case fastMinutes:
+		// the start of the minute on the wall clock, which Truncate doesn't give for offsets with seconds
+		next = t.Add(-time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()) + time.Duration(s.fast.second)*time.Second)
+
+		if m := t.Minute(); m%s.fast.step != 0 || !next.After(t) {
+			next = next.Add(time.Duration(s.fast.step-m%s.fast.step) * time.Minute)
+		}
+	case fastDaily:
+		year, month, day := t.Date()
+
+		next = time.Date(year, month, day, s.fast.hour, s.fast.minute, s.fast.second, 0, s.tz)
+		if !next.After(t) {
+			next = time.Date(year, month, day+1, s.fast.hour, s.fast.minute, s.fast.second, 0, s.tz)
+		}
+
+		if next.Hour() != s.fast.hour || next.Minute() != s.fast.minute || next.Second() != s.fast.second {
+			return time.Time{}, false
+		}
+	}
+
+	if !end.IsZero() && !next.Before(end) {
+		return time.Time{}, false
+	}
+
+	return next, true
+}
@@ -0,0 +1,199 @@
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// returns the schedule as a cron expression
+//
+// the expression is rebuilt from the matched values, so it may differ from the parsed one (e.g. "*/20" is returned as "0,20,40"). Schedules running at seconds other than 0 start with a seconds field and always end with the year field, so their seven fields can't be mistaken for a minute to year expression, and the day of week field always numbers sunday as 0
+//
+// the "CRON_TZ=" prefix is kept when the timezone was given by the parsed expression
+func (s *Cron) String() string {
+	if !s.tzPrefix || s.tz == nil {
+		return s.expression()
+	}
+
+	// String can't fail, so the offsets that can't be written keep the name of the zone
+	name, err := zoneName(s.tz)
+	if err != nil {
+		name = s.tz.String()
+	}
+
+	return "CRON_TZ=" + name + " " + s.expression()
+}
+
+// returns the fields of the schedule as an expression, without a timezone prefix
+func (s *Cron) expression() string {
+	fields := []string{
+		formatField(s.minute, boundMinute),
+		formatField(s.hour, boundHour),
+		s.formatDOM(),
+		formatField(s.month, boundMonth),
+		s.formatDOW(),
+	}
+
+	// the seconds are only written when the schedule doesn't run at second 0 only
+	seconds := s.second != 1
+
+	switch {
+	case s.year != nil:
+		fields = append(fields, formatValues(s.year))
+	case seconds:
+		fields = append(fields, "*")
+	}
+
+	if seconds {
+		fields = append([]string{formatField(s.second, boundSecond)}, fields...)
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// implements encoding.TextMarshaler, returning the expression of String with a "CRON_TZ=" prefix for the timezone of the schedule, so it is kept by UnmarshalText
+//
+// zones without changes of offset, like the ones built with time.FixedZone, are written as their offset (e.g. "CRON_TZ=UTC+01:00"). It returns ErrUnsupportedZone when the offset is not a whole number of minutes or is beyond 14 hours
+func (s *Cron) MarshalText() ([]byte, error) {
+	if s.tz == nil {
+		return []byte(s.expression()), nil
+	}
+
+	name, err := zoneName(s.tz)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte("CRON_TZ=" + name + " " + s.expression()), nil
+}
+
+// implements encoding.TextUnmarshaler, parsing the expression into the schedule
+//
+// expressions without a "CRON_TZ=" or "TZ=" prefix keep the timezone already set in the receiver, or UTC if there is none. Expressions with seven fields start with a seconds field, as written by String
+func (s *Cron) UnmarshalText(text []byte) error {
+	return s.parseText(string(text))
+}
+
+// parses an expression written by String into the schedule, in the timezone of the receiver or UTC if it has none
+func (s *Cron) parseText(expr string) error {
+	tz := s.tz
+	if tz == nil {
+		tz = time.UTC
+	}
+
+	opts := []Option{WithLocation(tz)}
+
+	// the seconds are only written along with the year
+	fields := strings.Fields(expr)
+	if len(fields) > 0 && (strings.HasPrefix(fields[0], "CRON_TZ=") || strings.HasPrefix(fields[0], "TZ=")) {
+		fields = fields[1:]
+	}
+
+	if len(fields) == len(layoutFields) {
+		opts = append(opts, WithSeconds())
+	}
+
+	c, err := ParseWithOptions(expr, opts...)
+	if err != nil {
+		return err
+	}
+
+	*s = *c
+
+	return nil
+}
+
+// returns the field expression for the bitset, using "*" when all the allowed values are set
+func formatField[T bitset8 | bitset16 | bitset32 | bitset64](b T, bounds fieldBounds) string {
+	if b == buildBitset[T](bounds.min, bounds.max, 1) {
+		return "*"
+	}
+
+	return formatValues(bitsetValues[T, int](b, bounds))
+}
+
+// returns the sorted values as a list, using ranges for consecutive values
+func formatValues(values []int) string {
+	var parts []string
+
+	for i := 0; i < len(values); i++ {
+		// extend the range while the next values are consecutive
+		end := i
+		for end+1 < len(values) && values[end+1] == values[end]+1 {
+			end++
+		}
+
+		if end > i {
+			parts = append(parts, strconv.Itoa(values[i])+"-"+strconv.Itoa(values[end]))
+		} else {
+			parts = append(parts, strconv.Itoa(values[i]))
+		}
+
+		i = end
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// returns the day of month field expression, including the days counted back from the last day of the month and the nearest weekdays
+func (s *Cron) formatDOM() string {
+	if s.domLast == 0 && s.domWeekday == 0 {
+		return formatField(s.dom, boundDOM)
+	}
+
+	var parts []string
+	if s.dom != 0 {
+		parts = append(parts, formatField(s.dom, boundDOM))
+	}
+
+	for d := boundDOM.min; d <= boundDOM.max; d++ {
+		if s.domWeekday&(1<<d) != 0 {
+			parts = append(parts, strconv.Itoa(d)+"W")
+		}
+	}
+
+	if s.domWeekday&1 != 0 {
+		parts = append(parts, "LW")
+	}
+
+	if s.domLast&1 != 0 {
+		parts = append(parts, "L")
+	}
+
+	for n := 1; n < boundDOM.max; n++ {
+		if s.domLast&(1<<n) != 0 {
+			parts = append(parts, "L-"+strconv.Itoa(n))
+		}
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// returns the day of week field expression, including the weekdays matching their nth or last occurrence in the month
+func (s *Cron) formatDOW() string {
+	if s.dowLast == 0 && s.dowNth == [7]bitset8{} {
+		return formatField(s.dow, boundDOW)
+	}
+
+	var parts []string
+	if s.dow != 0 {
+		parts = append(parts, formatField(s.dow, boundDOW))
+	}
+
+	for w := boundDOW.min; w <= boundDOW.max; w++ {
+		for n := 1; n <= 5; n++ {
+			if s.dowNth[w]&(1<<n) != 0 {
+				parts = append(parts, strconv.Itoa(w)+"#"+strconv.Itoa(n))
+			}
+		}
+	}
+
+	for w := boundDOW.min; w <= boundDOW.max; w++ {
+		if s.dowLast&(1<<w) != 0 {
+			parts = append(parts, strconv.Itoa(w)+"L")
+		}
+	}
+
+	return strings.Join(parts, ",")
+}
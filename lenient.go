@@ -0,0 +1,222 @@
+package cron
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// a fix applied by ParseLenient to the expression
+	Correction struct {
+		// the name of the corrected field, empty when the correction is not related to a single field
+		Field     string
+		Original  string
+		Corrected string
+	}
+)
+
+var (
+	// the bounds of the fields, in the order they appear in the expression
+	fieldsBounds = []fieldBounds{boundMinute, boundHour, boundDOM, boundMonth, boundDOWInput, boundYear}
+)
+
+// returns the same result as Parse, but it fixes the recoverable issues of the expression and reports the applied corrections in the order of the expression
+//
+// recoverable issues are reversed ranges ("5-1" => "1-5"), values one above the max of the field, and extra trailing fields (e.g. a command left in an imported crontab line). Ranges ending one above the max are clamped ("50-60" => "50-59"), while a single 60 minutes or 24 hours is carried over to the next unit ("60 9 * * *" => "0 10 * * *"); it is not corrected when the carry would change other times, like "60 9,17 * * *" or "0 24 1 * *". A single day of month, month or year above the max, like 32 or 13, has no next unit to carry over to, so it is an error
+func ParseLenient(expr string, tz *time.Location) (*Cron, []Correction, error) {
+	if err := checkLength(expr); err != nil {
+		return nil, nil, err
+	}
+
+	expr = strings.TrimSpace(expr)
+
+	// the timezone prefix is not a field, so it is resolved before correcting the fields
+	zone, expr, _, err := NewParser().cutZone(expr, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if zone != nil {
+		tz = zone
+	}
+
+	if strings.HasPrefix(expr, "@") {
+		c, err := Parse(expr, tz)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		c.tzPrefix = zone != nil
+
+		return c, nil, nil
+	}
+
+	// the extra trailing fields are reported after the corrections of the fields before them
+	var corrections, trailing []Correction
+
+	fields := strings.Fields(expr)
+	if len(fields) > len(fieldsBounds) {
+		trailing = append(trailing, Correction{
+			Original:  strings.Join(fields[len(fieldsBounds):], " "),
+			Corrected: "",
+		})
+		fields = fields[:len(fieldsBounds)]
+	}
+
+	// every correction fixes one token, so the expression can't need more attempts than its length
+	for attempts := 0; attempts <= len(expr); attempts++ {
+		c, err := Parse(strings.Join(fields, " "), tz)
+		if err == nil {
+			c.tzPrefix = zone != nil
+
+			return c, append(corrections, trailing...), nil
+		}
+
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			return nil, append(corrections, trailing...), err
+		}
+
+		i := fieldIndex(parseErr.Field)
+		if i < 0 {
+			return nil, append(corrections, trailing...), err
+		}
+
+		corrected, wrapped, ok := correctToken(parseErr.Token, fieldsBounds[i])
+
+		// the value is the min of the next unit, so the next field is carried over
+		var carry *Correction
+		if ok && wrapped {
+			carry, ok = carryOver(fields, i, parseErr.Token)
+		}
+
+		// an invalid year in the last field is most likely the begining of a command
+		if !ok && i == len(fieldsBounds)-1 {
+			corrections = append(corrections, Correction{Original: fields[i], Corrected: ""})
+			fields = fields[:i]
+			continue
+		}
+
+		if !ok {
+			return nil, append(corrections, trailing...), err
+		}
+
+		parts := strings.Split(fields[i], ",")
+		for j, part := range parts {
+			if part == parseErr.Token {
+				parts[j] = corrected
+				break
+			}
+		}
+
+		fields[i] = strings.Join(parts, ",")
+		corrections = append(corrections, Correction{Field: parseErr.Field, Original: parseErr.Token, Corrected: corrected})
+
+		if carry != nil {
+			fields[i+1] = carry.Corrected
+			corrections = append(corrections, *carry)
+		}
+	}
+
+	return nil, append(corrections, trailing...), ErrInvalidExpression
+}
+
+// returns the position of the field with the given name in the expression, or -1 if it is not one of the fields
+func fieldIndex(name string) int {
+	for i, b := range fieldsBounds {
+		if b.name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// returns the correction of the field after the i-th one for a value of the i-th one that wrapped around to its min, nil if the field needs none (e.g. "*"), or false if the carry would change other times
+//
+// only the minutes carry over to a single hour, and the hours to the days when they are every day
+func carryOver(fields []string, i int, token string) (*Correction, bool) {
+	// other values of the field would move to the next unit too
+	if fields[i] != token {
+		return nil, false
+	}
+
+	switch fieldsBounds[i].name {
+	case boundMinute.name:
+		if fields[i+1] == "*" {
+			return nil, true
+		}
+
+		hour, err := strconv.Atoi(fields[i+1])
+		if err != nil || !boundHour.contains(hour+1) {
+			return nil, false
+		}
+
+		return &Correction{Field: boundHour.name, Original: fields[i+1], Corrected: strconv.Itoa(hour + 1)}, true
+	case boundHour.name:
+		everyDay := func(field string) bool { return field == "*" || field == "?" }
+
+		return nil, everyDay(fields[i+1]) && everyDay(fields[i+3])
+	}
+
+	return nil, false
+}
+
+// returns the token with its recoverable issue fixed and whether a single value wrapped around to the min of the field, or false if it can't be fixed
+func correctToken(token string, bounds fieldBounds) (string, bool, bool) {
+	rangePart, step, hasStep := strings.Cut(token, "/")
+
+	lowAndHigh := strings.Split(rangePart, "-")
+	values := make([]int, len(lowAndHigh))
+	for i, v := range lowAndHigh {
+		n, err := bounds.value(v)
+		if err != nil {
+			return "", false, false
+		}
+
+		values[i] = n
+	}
+
+	fixed, wrapped := false, false
+
+	// a single value one above the max wraps around to the min (e.g. 24h => 0h) when the field starts at 0, ranges are clamped
+	for i, v := range values {
+		if v != bounds.max+1 {
+			continue
+		}
+
+		switch {
+		case len(values) > 1:
+			values[i] = bounds.max
+		case bounds.min == 0:
+			values[i], wrapped = bounds.min, true
+		default:
+			// a day of month 32 or a month 13 is a typo rather than the last one
+			return "", false, false
+		}
+
+		fixed = true
+	}
+
+	if len(values) == 2 && values[1] < values[0] {
+		values[0], values[1] = values[1], values[0]
+		fixed = true
+	}
+
+	if !fixed {
+		return "", false, false
+	}
+
+	corrected := strconv.Itoa(values[0])
+	if len(values) == 2 {
+		corrected += "-" + strconv.Itoa(values[1])
+	}
+
+	if hasStep {
+		corrected += "/" + step
+	}
+
+	return corrected, wrapped, true
+}
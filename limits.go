@@ -0,0 +1,50 @@
+package cron
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	// the max length in bytes of an expression
+	MaxExpressionLength = 1024
+	// the max number of comma separated items in a field, which is enough to list every value of the day of month field with its special tokens
+	MaxListItems = 100
+	// the max number of comma separated items in all the fields of an expression
+	MaxParts = 200
+)
+
+var (
+	ErrExpressionTooComplex = errors.New("the expression exceeds the limits of length or number of items")
+)
+
+// returns ErrExpressionTooComplex if the expression is longer than MaxExpressionLength, before it is split into fields
+func checkLength(expr string) error {
+	if len(expr) > MaxExpressionLength {
+		return ErrExpressionTooComplex
+	}
+
+	return nil
+}
+
+// returns ErrExpressionTooComplex if a field has more than MaxListItems items, or all the fields more than MaxParts
+//
+// the items are counted before the fields are split, so huge lists are rejected without allocating them
+func checkParts(fields []string) error {
+	parts := 0
+
+	for _, field := range fields {
+		items := strings.Count(field, ",") + 1
+		if items > MaxListItems {
+			return ErrExpressionTooComplex
+		}
+
+		parts += items
+	}
+
+	if parts > MaxParts {
+		return ErrExpressionTooComplex
+	}
+
+	return nil
+}
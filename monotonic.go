@@ -0,0 +1,74 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+type (
+	// how a MonotonicSchedule treats its last occurrence
+	MonotonicMode int
+
+	// a schedule that never returns an occurrence before the last one it returned, so a change of cadence (e.g. of a DynamicSchedule) or a reference time moving back can't run a period again
+	MonotonicSchedule struct {
+		schedule Schedule
+		mode     MonotonicMode
+
+		mu   sync.Mutex
+		last time.Time
+	}
+)
+
+const (
+	// returns only the occurrences after the last one, so an occurrence already run never runs again
+	AfterLast MonotonicMode = iota
+	// returns the last occurrence again when asked for the next one before it; e.g., to retry a run that failed
+	FromLast
+)
+
+// returns a schedule with the occurrences of s that are after the last one returned, or also the last one itself with FromLast
+//
+// last is the last occurrence already run (e.g. persisted across restarts), or the zero time if there is none. Every occurrence returned by Next becomes the last one, so ask for the next occurrence once per run
+func NewMonotonicSchedule(s Schedule, last time.Time, mode MonotonicMode) *MonotonicSchedule {
+	return &MonotonicSchedule{
+		schedule: s,
+		mode:     mode,
+		last:     last,
+	}
+}
+
+// returns the first occurrence of the schedule after t, and after the last one returned (or not before it, with FromLast)
+func (m *MonotonicSchedule) Next(t time.Time) (time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var next time.Time
+	var err error
+
+	switch {
+	case !t.Before(m.last):
+		next, err = m.schedule.Next(t)
+	case m.mode == FromLast:
+		next, err = nextFrom(m.schedule, m.last)
+	default:
+		next, err = m.schedule.Next(m.last)
+	}
+
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if next.After(m.last) {
+		m.last = next
+	}
+
+	return next, nil
+}
+
+// returns the last occurrence returned by Next, or the one the schedule was created with
+func (m *MonotonicSchedule) Last() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.last
+}
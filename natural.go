@@ -0,0 +1,581 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// the state of ParseNatural: the words of the sentence and the fields built from them
+	naturalExpr struct {
+		words []string
+		pos   int
+		// second, minute, hour, day of month, month, day of week and year; empty time fields are set at the end
+		fields []string
+		// the index of the time field repeated by "every", or -1
+		every int
+		// the period of "every week" and "every month", whose day defaults to sunday and the 1st
+		weekly, monthly bool
+		// the ranges of hours of "between", which keep the step of "every n hours"
+		between []string
+		// the phrase that set each of the fields, to reject the phrases setting them again
+		set [7]string
+	}
+)
+
+var (
+	// the words of the ordinals, from 1
+	naturalOrdinals = []string{"first", "second", "third", "fourth", "fifth"}
+)
+
+// parses an English description of a schedule, like the ones of admin UIs, and returns a new schedule representing it
+//
+// the description is made of the phrases "every [n] seconds|minutes|hours" (with n dividing a minute, an hour or a day, so the runs are evenly spaced), "every day|week|month|weekday|<weekday>", "hourly", "daily", "weekly", "monthly", "yearly", "at <times>", "between <time> and <time>", "on <weekdays>|weekdays|weekends", "on the <nth> [day]", "on the first|...|last <weekday>" and "in <months>", in any order, each part of the schedule set by a single phrase; e.g., "every 15 minutes on weekdays between 9am and 5pm" or "at 9:30 and 17:30 on the 1st and 15th". Lists are separated by commas or "and". The times of "between" are on the hour and its end is excluded, and the unset times of day are midnight
+//
+// it returns an error when the description can't be understood
+func ParseNatural(expr string, tz *time.Location) (*Cron, error) {
+	if err := checkLength(expr); err != nil {
+		return nil, err
+	}
+
+	e := &naturalExpr{
+		words:  strings.Fields(strings.ToLower(strings.ReplaceAll(expr, ",", " "))),
+		fields: []string{"", "", "", "*", "*", "*", "*"},
+		every:  -1,
+	}
+
+	if len(e.words) == 0 {
+		return nil, &ParseError{Token: expr, Reason: "the description is empty"}
+	}
+
+	for e.pos < len(e.words) {
+		word := e.next()
+
+		var err error
+		switch word {
+		case "every", "each":
+			err = e.parseEvery()
+		case "hourly":
+			if err = e.claim("hourly", 2); err == nil {
+				e.every = 2
+			}
+		case "daily", "nightly":
+		case "weekly":
+			e.weekly = true
+		case "monthly":
+			e.monthly = true
+		case "yearly", "annually":
+			if err = e.claim("yearly", 3, 4); err == nil {
+				e.fields[3], e.fields[4] = "1", "1"
+			}
+		case "at":
+			err = e.parseTimes()
+		case "between", "from":
+			err = e.parseBetween()
+		case "on":
+			err = e.parseDays("on")
+		case "in", "during":
+			err = e.parseMonths()
+		default:
+			err = e.unexpected(word)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if e.between != nil {
+		step := ""
+		if e.every == 2 {
+			step = strings.TrimPrefix(e.fields[2], "*")
+		}
+
+		e.fields[2] = strings.Join(e.between, step+",") + step
+	}
+
+	// the time fields shorter than the repeated one are 0, and the longer ones match every value
+	for i := 0; i < 3; i++ {
+		switch {
+		case e.fields[i] != "":
+		case e.every >= 0 && i > e.every:
+			e.fields[i] = "*"
+		default:
+			e.fields[i] = "0"
+		}
+	}
+
+	if e.weekly && e.fields[5] == "*" {
+		e.fields[5] = "0"
+	}
+
+	if e.monthly && e.fields[3] == "*" && e.fields[5] == "*" {
+		e.fields[3] = "1"
+	}
+
+	return parseFields(e.fields, boundDOWInput, tz)
+}
+
+// returns the same result as ParseNatural, but it panics when the description can't be understood
+func MustParseNatural(expr string, tz *time.Location) *Cron {
+	c, err := ParseNatural(expr, tz)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+// returns the next word, or an empty string at the end of the sentence
+func (e *naturalExpr) next() string {
+	if e.pos >= len(e.words) {
+		return ""
+	}
+
+	e.pos++
+
+	return e.words[e.pos-1]
+}
+
+// returns the next word without consuming it
+func (e *naturalExpr) peek() string {
+	return e.peekAt(0)
+}
+
+// returns the word n words after the next one without consuming it, or an empty string after the end of the sentence
+func (e *naturalExpr) peekAt(n int) string {
+	if e.pos+n >= len(e.words) {
+		return ""
+	}
+
+	return e.words[e.pos+n]
+}
+
+// consumes the next word if it is one of the given ones, and reports whether it was
+func (e *naturalExpr) skip(words ...string) bool {
+	for _, w := range words {
+		if e.peek() == w {
+			e.pos++
+			return true
+		}
+	}
+
+	return false
+}
+
+// records that the phrase sets the fields, or returns an error if an earlier phrase already set any of them; e.g., "at 9am at 5pm" or "hourly at 9am"
+//
+// the hours of "between" are the only ones that can be set twice, by "every n hours" or "hourly" which repeat them
+func (e *naturalExpr) claim(phrase string, fields ...int) error {
+	for _, i := range fields {
+		prev := e.set[i]
+
+		repeated := i == 2 && (prev == "between") != (phrase == "between") && prev != "at" && phrase != "at"
+		if prev != "" && !repeated {
+			return &ParseError{
+				Token:      strings.Join(e.words, " "),
+				Reason:     fmt.Sprintf("'%s' sets the %s already set by '%s'", phrase, randomBounds[i].name, prev),
+				Suggestion: "use a single phrase for each part of the schedule, or combine the schedules with ParseMulti",
+			}
+		}
+
+		e.set[i] = phrase
+	}
+
+	return nil
+}
+
+// returns the error for a word that is not expected at its position
+func (e *naturalExpr) unexpected(word string) error {
+	if word == "" {
+		return &ParseError{Token: strings.Join(e.words, " "), Reason: "the description ends unexpectedly"}
+	}
+
+	return &ParseError{
+		Token:      word,
+		Reason:     fmt.Sprintf("unexpected '%s'", word),
+		Suggestion: "use phrases like \"every 15 minutes\", \"at 9am\", \"between 9am and 5pm\", \"on weekdays\" or \"in january\"",
+	}
+}
+
+// parses "[n] seconds|minutes|hours", "day", "week", "month", "weekday", "weekend" or a list of weekdays after "every"
+func (e *naturalExpr) parseEvery() error {
+	n := 1
+	if v, err := strconv.Atoi(e.peek()); err == nil {
+		n = v
+		e.pos++
+	}
+
+	if n < 1 {
+		return &ParseError{Token: strconv.Itoa(n), Reason: "the interval must be a positive number"}
+	}
+
+	// "every second monday" is an ordinal, not a unit
+	if _, isOrdinal := naturalOrdinal(e.peek()); isOrdinal && n == 1 && naturalWeekday(e.peekAt(1)) >= 0 {
+		return e.parseDays("every")
+	}
+
+	word := e.next()
+	unit := strings.TrimSuffix(word, "s")
+
+	step := "*"
+	if n > 1 {
+		step = "*/" + strconv.Itoa(n)
+	}
+
+	switch unit {
+	case "second", "minute", "hour":
+		// the steps restart every minute, hour or day, so the interval must divide it to be even
+		units := map[string]int{"second": 60, "minute": 60, "hour": 24}[unit]
+		if units%n != 0 {
+			return &ParseError{
+				Token:      strconv.Itoa(n) + " " + word,
+				Reason:     fmt.Sprintf("every %d %s can't be represented as a cron expression, since %d doesn't divide %d", n, word, n, units),
+				Suggestion: fmt.Sprintf("use a number of %ss dividing %d", unit, units),
+			}
+		}
+
+		every := map[string]int{"second": 0, "minute": 1, "hour": 2}[unit]
+		if err := e.claim("every", every); err != nil {
+			return err
+		}
+
+		e.every = every
+		e.fields[every] = step
+		return nil
+	}
+
+	if n > 1 {
+		return &ParseError{
+			Token:      word,
+			Reason:     fmt.Sprintf("every %d %s can't be represented as a cron expression", n, word),
+			Suggestion: "use seconds, minutes or hours, or list the days",
+		}
+	}
+
+	switch unit {
+	case "day", "night":
+	case "week":
+		e.weekly = true
+	case "month":
+		e.monthly = true
+	default:
+		e.pos--
+		return e.parseDays("every")
+	}
+
+	return nil
+}
+
+// parses a list of times of day after "at"; the times must share either the minute or the hour
+func (e *naturalExpr) parseTimes() error {
+	var hours, minutes []int
+
+	for {
+		h, m, err := e.parseTime()
+		if err != nil {
+			return err
+		}
+
+		hours, minutes = append(hours, h), append(minutes, m)
+
+		if !e.skip("and") && !e.startsTime() {
+			break
+		}
+	}
+
+	if err := e.claim("at", 1, 2); err != nil {
+		return err
+	}
+
+	switch {
+	case allEqual(minutes):
+		e.fields[1], e.fields[2] = strconv.Itoa(minutes[0]), joinInts(hours)
+	case allEqual(hours):
+		e.fields[1], e.fields[2] = joinInts(minutes), strconv.Itoa(hours[0])
+	default:
+		return &ParseError{
+			Token:      strings.Join(e.words, " "),
+			Reason:     "the times differ in both the hour and the minute",
+			Suggestion: "parse each time separately and combine them with ParseMulti",
+		}
+	}
+
+	return nil
+}
+
+// parses "<time> and|to <time>" after "between", matching the hours from the first time to the second one
+//
+// the times must be on the hour, since the range of hours can't start or end within an hour
+func (e *naturalExpr) parseBetween() error {
+	from, err := e.parseHour()
+	if err != nil {
+		return err
+	}
+
+	if !e.skip("and", "to") {
+		return e.unexpected(e.next())
+	}
+
+	to, err := e.parseHour()
+	if err != nil {
+		return err
+	}
+
+	if err := e.claim("between", 2); err != nil {
+		return err
+	}
+
+	// the end is excluded: "between 9am and 5pm" ends at 16:59
+	to = (to + 23) % 24
+
+	if to < from {
+		e.between = []string{fmt.Sprintf("%d-23", from), fmt.Sprintf("0-%d", to)}
+	} else {
+		e.between = []string{fmt.Sprintf("%d-%d", from, to)}
+	}
+
+	return nil
+}
+
+// parses a list of weekdays, "weekdays", "weekends", days of month ("the 1st", "the last day") or nth weekdays of the month ("the first monday"), optionally followed by "of the month", after the phrase
+func (e *naturalExpr) parseDays(phrase string) error {
+	var doms, dows []string
+
+	for {
+		e.skip("the")
+		word := e.next()
+
+		n, isOrdinal := naturalOrdinal(word)
+		w := naturalWeekday(word)
+
+		switch {
+		case word == "weekday" || word == "weekdays":
+			dows = append(dows, "1-5")
+		case word == "weekend" || word == "weekends":
+			dows = append(dows, "0,6")
+		case w >= 0:
+			dows = append(dows, strconv.Itoa(w))
+		case isOrdinal && naturalWeekday(e.peek()) >= 0:
+			w = naturalWeekday(e.next())
+
+			if n < 0 {
+				dows = append(dows, strconv.Itoa(w)+"L")
+			} else {
+				dows = append(dows, strconv.Itoa(w)+"#"+strconv.Itoa(n))
+			}
+		case isOrdinal:
+			e.skip("day")
+
+			if n < 0 {
+				doms = append(doms, "L")
+			} else {
+				doms = append(doms, strconv.Itoa(n))
+			}
+		default:
+			return e.unexpected(word)
+		}
+
+		if !e.skip("and") && !e.startsDay() {
+			break
+		}
+	}
+
+	if e.skip("of") {
+		e.skip("the", "every", "each")
+
+		if !e.skip("month") {
+			return e.unexpected(e.next())
+		}
+	}
+
+	if doms != nil {
+		if err := e.claim(phrase, 3); err != nil {
+			return err
+		}
+
+		e.fields[3] = strings.Join(doms, ",")
+	}
+
+	if dows != nil {
+		if err := e.claim(phrase, 5); err != nil {
+			return err
+		}
+
+		e.fields[5] = strings.Join(dows, ",")
+	}
+
+	return nil
+}
+
+// parses a list of month names after "in"
+func (e *naturalExpr) parseMonths() error {
+	var months []int
+
+	for {
+		word := e.next()
+
+		m := naturalMonth(word)
+		if m < 0 {
+			return e.unexpected(word)
+		}
+
+		months = append(months, m)
+
+		if !e.skip("and") && naturalMonth(e.peek()) < 0 {
+			break
+		}
+	}
+
+	if err := e.claim("in", 4); err != nil {
+		return err
+	}
+
+	e.fields[4] = joinInts(months)
+
+	return nil
+}
+
+// parses a time of day: "noon", "midnight", "9", "9am", "9 pm", "9:30am" or "17:45"
+func (e *naturalExpr) parseTime() (int, int, error) {
+	word := e.next()
+
+	switch word {
+	case "noon", "midday":
+		return 12, 0, nil
+	case "midnight":
+		return 0, 0, nil
+	}
+
+	clock, meridiem := word, ""
+	for _, suffix := range []string{"am", "pm"} {
+		if strings.HasSuffix(word, suffix) {
+			clock, meridiem = strings.TrimSuffix(word, suffix), suffix
+		}
+	}
+
+	if meridiem == "" && e.skip("am") {
+		meridiem = "am"
+	} else if meridiem == "" && e.skip("pm") {
+		meridiem = "pm"
+	}
+
+	hh, mm, _ := strings.Cut(clock, ":")
+	if mm == "" {
+		mm = "0"
+	}
+
+	h, errH := strconv.Atoi(hh)
+	m, errM := strconv.Atoi(mm)
+
+	valid := errH == nil && errM == nil && h >= 0 && h <= 23 && m >= 0 && m <= 59
+	if meridiem != "" {
+		valid = valid && h >= 1 && h <= 12
+	}
+
+	if !valid {
+		return 0, 0, &ParseError{
+			Token:      word,
+			Reason:     fmt.Sprintf("'%s' is not a time of day", word),
+			Suggestion: "use 9am, 9:30pm, 17:45, noon or midnight",
+		}
+	}
+
+	switch {
+	case meridiem == "am" && h == 12:
+		h = 0
+	case meridiem == "pm" && h < 12:
+		h += 12
+	}
+
+	return h, m, nil
+}
+
+// parses a time of day on the hour for "between", and returns its hour
+func (e *naturalExpr) parseHour() (int, error) {
+	start := e.pos
+
+	h, m, err := e.parseTime()
+	if err != nil {
+		return 0, err
+	}
+
+	if m != 0 {
+		return 0, &ParseError{
+			Token:      strings.Join(e.words[start:e.pos], " "),
+			Reason:     "the times of 'between' must be on the hour",
+			Suggestion: "use times like 9am or 17:00, and \"at\" for the minutes",
+		}
+	}
+
+	return h, nil
+}
+
+// reports whether the next word starts a time of day, for the lists separated by commas
+func (e *naturalExpr) startsTime() bool {
+	word := e.peek()
+
+	return word == "noon" || word == "midday" || word == "midnight" || word != "" && word[0] >= '0' && word[0] <= '9'
+}
+
+// reports whether the next word starts a day, for the lists separated by commas
+func (e *naturalExpr) startsDay() bool {
+	word := e.peek()
+	_, isOrdinal := naturalOrdinal(word)
+
+	return isOrdinal || naturalWeekday(word) >= 0 || strings.HasPrefix(word, "weekday") || strings.HasPrefix(word, "weekend")
+}
+
+// returns the weekday (0-6) of a full, abbreviated or plural weekday name, or -1 if it is not one
+func naturalWeekday(word string) int {
+	if len(word) > 6 && strings.HasSuffix(word, "days") {
+		word = strings.TrimSuffix(word, "s")
+	}
+
+	return systemdWeekday(word)
+}
+
+// returns the month (1-12) of a full or abbreviated month name, or -1 if it is not one
+func naturalMonth(word string) int {
+	for m := time.January; m <= time.December; m++ {
+		if len(word) >= 3 && strings.HasPrefix(strings.ToLower(m.String()), word) {
+			return int(m)
+		}
+	}
+
+	return -1
+}
+
+// returns the number of an ordinal ("first", "2nd", "15th"), -1 for "last", or false if the word is not an ordinal
+func naturalOrdinal(word string) (int, bool) {
+	if word == "last" {
+		return -1, true
+	}
+
+	for i, ordinal := range naturalOrdinals {
+		if word == ordinal {
+			return i + 1, true
+		}
+	}
+
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		if n, err := strconv.Atoi(strings.TrimSuffix(word, suffix)); err == nil && strings.HasSuffix(word, suffix) {
+			return n, true
+		}
+	}
+
+	return 0, false
+}
+
+// reports whether all the values are the same
+func allEqual(values []int) bool {
+	for _, v := range values {
+		if v != values[0] {
+			return false
+		}
+	}
+
+	return true
+}
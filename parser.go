@@ -0,0 +1,417 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type (
+	// configures a Parser; see the With... functions
+	Option func(*Parser)
+
+	// a set of fields of the expressions, combined with "|"
+	Field uint
+
+	// parses expressions with a set of options; the zero value is not valid, use NewParser
+	Parser struct {
+		fields Field
+		// the numbering of the day of week field; the min is sunday
+		dowBounds fieldBounds
+		macros    bool
+		strict    bool
+		tz        *time.Location
+		zones     ZoneProvider
+		rand      *rand.Rand
+	}
+)
+
+const (
+	FieldSecond Field = 1 << iota
+	FieldMinute
+	FieldHour
+	FieldDayOfMonth
+	FieldMonth
+	FieldDayOfWeek
+	FieldYear
+	// the seconds field may be omitted, running at second 0
+	FieldSecondOptional
+	// the year field may be omitted, matching every year
+	FieldYearOptional
+
+	// the fields accepted by Parse
+	StandardFields = FieldMinute | FieldHour | FieldDayOfMonth | FieldMonth | FieldDayOfWeek | FieldYearOptional
+)
+
+var (
+	// the fields in the order they appear in the expressions, with the value used when the layout doesn't have them
+	layoutFields = []struct {
+		field, optional Field
+		missing         string
+	}{
+		{FieldSecond, FieldSecondOptional, "0"},
+		{FieldMinute, 0, "0"},
+		{FieldHour, 0, "0"},
+		{FieldDayOfMonth, 0, "*"},
+		{FieldMonth, 0, "*"},
+		{FieldDayOfWeek, 0, "*"},
+		{FieldYear, FieldYearOptional, "*"},
+	}
+
+	// the bounds of the fields checked by the strict mode, from the seconds to the year
+	strictBounds = []fieldBounds{boundSecond, boundMinute, boundHour, boundDOM, boundMonth, boundDOWInput, boundYear}
+)
+
+// returns a parser configured with the options
+//
+// without options it parses the same expressions as Parse, in UTC
+func NewParser(opts ...Option) *Parser {
+	p := &Parser{
+		fields:    StandardFields,
+		dowBounds: boundDOWInput,
+		macros:    true,
+		tz:        time.UTC,
+		zones:     SystemZones,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// the expressions start with a seconds field, so they have six fields and an optional seventh year field. Macros still run at second 0
+func WithSeconds() Option {
+	return func(p *Parser) {
+		p.fields = p.fields | FieldSecond
+	}
+}
+
+// the expressions have the given fields, always in the order second, minute, hour, day of month, month, day of week and year; e.g., FieldHour | FieldDayOfWeek parses "9 MON-FRI"
+//
+// the fields the layout doesn't have match their min value for the time (second, minute and hour) and every value for the date. Either the seconds or the year may be optional, not both. Macros still expand to the standard fields
+//
+// it panics when the layout has no fields or two optional fields, which is a programming error
+func WithFields(fields Field) Option {
+	if fields == 0 {
+		panic("cron: the layout has no fields")
+	}
+
+	if fields&FieldSecondOptional != 0 && fields&FieldYearOptional != 0 {
+		panic("cron: only one field of the layout may be optional")
+	}
+
+	return func(p *Parser) {
+		p.fields = fields
+	}
+}
+
+// parses the expressions like NCrontab, the parser of Hangfire before 1.7: five fields, or six starting with the seconds, and the day of week from 0 (sunday) to 6, so 7 is rejected. Macros are rejected too, and the names of the months and days of week are accepted as in Parse
+//
+// the days of month and of week must both match, as in NCrontab, so its expressions run at the same times
+func WithNCrontab() Option {
+	return func(p *Parser) {
+		p.fields = FieldSecondOptional | FieldMinute | FieldHour | FieldDayOfMonth | FieldMonth | FieldDayOfWeek
+		p.dowBounds = boundDOW
+		p.macros = false
+	}
+}
+
+// rejects the macros (@daily, @hourly, ...)
+func WithoutMacros() Option {
+	return func(p *Parser) {
+		p.macros = false
+	}
+}
+
+// rejects the expressions that are valid but probably not what was meant: values repeated in a field (e.g. "1,1-5" or "0,7" in the day of week field), steps larger than their range, which match a single value (e.g. "0-10/20"), and days of month that don't exist in any of the months (e.g. "0 0 30 2 *")
+func WithStrict() Option {
+	return func(p *Parser) {
+		p.strict = true
+	}
+}
+
+// the timezone of the schedules whose expression has no "CRON_TZ=" or "TZ=" prefix; UTC by default
+func WithLocation(tz *time.Location) Option {
+	return func(p *Parser) {
+		p.tz = tz
+	}
+}
+
+// resolves the timezones of the "CRON_TZ=" and "TZ=" prefixes with the given provider; SystemZones by default
+func WithZoneProvider(zones ZoneProvider) Option {
+	return func(p *Parser) {
+		p.zones = zones
+	}
+}
+
+// picks the random values of "~" ranges with the given source, so the result is reproducible; the global source is used by default
+func WithRand(r *rand.Rand) Option {
+	return func(p *Parser) {
+		p.rand = r
+	}
+}
+
+// returns the same result as NewParser(opts...).Parse(expr)
+func ParseWithOptions(expr string, opts ...Option) (*Cron, error) {
+	return NewParser(opts...).Parse(expr)
+}
+
+// parses the expression and returns a new schedule representing the given spec
+//
+// it returns an error when the syntax of expression is wrong or it is not allowed by the options, or ErrExpressionTooComplex when it exceeds the limits
+func (p *Parser) Parse(expr string) (*Cron, error) {
+	if err := checkLength(expr); err != nil {
+		return nil, err
+	}
+
+	// the byte offset of expr in the given expression
+	offset := len(expr) - len(strings.TrimLeftFunc(expr, unicode.IsSpace))
+
+	expr = strings.TrimSpace(expr)
+	tz := p.tz
+
+	// a timezone embedded in the expression overrides the given one
+	loc, rest, restOffset, err := p.cutZone(expr, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	tzPrefix := loc != nil
+	if tzPrefix {
+		offset, expr, tz = restOffset, rest, loc
+	}
+
+	layout := p.fields
+
+	// expand the macros into their five fields
+	if strings.HasPrefix(expr, "@") {
+		if !p.macros {
+			return nil, &ParseError{
+				Token:      expr,
+				Offset:     offset,
+				Reason:     fmt.Sprintf("macro '%s' is not allowed", expr),
+				Suggestion: "use the fields of the expression",
+			}
+		}
+
+		macro, ok := macros[strings.ToLower(expr)]
+		if !ok {
+			return nil, &ParseError{
+				Token:      expr,
+				Offset:     offset,
+				Reason:     fmt.Sprintf("unknown macro '%s'", expr),
+				Suggestion: "use @yearly, @annually, @monthly, @weekly, @daily, @midnight or @hourly",
+			}
+		}
+
+		expr, layout, offset = macro, StandardFields, 0
+	}
+
+	f := splitFields(expr, offset)
+
+	fields, err := f.expand(layout)
+	if err != nil {
+		var parseErr *ParseError
+		if errors.As(err, &parseErr) {
+			parseErr.Offset = offset
+		}
+
+		return nil, err
+	}
+
+	if err := resolveRandom(fields, randomBounds, p.rand); err != nil {
+		return nil, f.locate(err)
+	}
+
+	c, err := parseFields(fields, p.dowBounds, tz)
+	if err != nil {
+		return nil, f.locate(err)
+	}
+
+	if p.strict {
+		if err := checkStrict(c, fields); err != nil {
+			return nil, f.locate(err)
+		}
+	}
+
+	c.tzPrefix = tzPrefix
+
+	return c, nil
+}
+
+// returns the location of the "CRON_TZ=" or "TZ=" prefix of the expression, the expression without it and its byte offset, or a nil location when the expression has no prefix
+//
+// the offset is the byte offset of the expression, to locate the errors
+func (p *Parser) cutZone(expr string, offset int) (*time.Location, string, int, error) {
+	if !strings.HasPrefix(expr, "CRON_TZ=") && !strings.HasPrefix(expr, "TZ=") {
+		return nil, expr, offset, nil
+	}
+
+	prefix, rest, _ := strings.Cut(expr, " ")
+	_, name, _ := strings.Cut(prefix, "=")
+
+	// time.LoadLocation returns UTC for an empty name
+	if name == "" {
+		return nil, "", 0, &ParseError{
+			Field:      "timezone",
+			Token:      prefix,
+			Offset:     offset,
+			Reason:     "the timezone is empty",
+			Suggestion: "use a timezone name like Europe/Madrid or an offset like UTC+01:00",
+		}
+	}
+
+	loc, err := p.loadLocation(name)
+	if err != nil {
+		return nil, "", 0, &ParseError{
+			Field:  "timezone",
+			Token:  prefix,
+			Offset: offset,
+			Reason: fmt.Sprintf("unknown timezone '%s'", name),
+		}
+	}
+
+	rest = strings.TrimSpace(rest)
+
+	return loc, rest, offset + len(expr) - len(rest), nil
+}
+
+// returns the location of a timezone name, which is either a fixed offset like "UTC+05:30" or a name resolved by the zone provider
+func (p *Parser) loadLocation(name string) (*time.Location, error) {
+	if loc, ok := fixedZone(name); ok {
+		return loc, nil
+	}
+
+	return p.zones.LoadLocation(name)
+}
+
+// returns the seven fields, from the seconds to the year, of the fields of an expression with the layout, and sets the indexes of the fields
+//
+// it returns an error when the number of fields doesn't match the layout
+func (f *exprFields) expand(layout Field) ([]string, error) {
+	fields := f.fields
+
+	// the indexes in layoutFields of the fields of the expression, and of the optional one
+	var indexes []int
+	optional := -1
+
+	for i, f := range layoutFields {
+		if layout&f.optional != 0 {
+			optional = len(indexes)
+		} else if layout&f.field == 0 {
+			continue
+		}
+
+		indexes = append(indexes, i)
+	}
+
+	switch {
+	case len(fields) == len(indexes):
+	case len(fields) == len(indexes)-1 && optional >= 0:
+		indexes = slices.Delete(indexes, optional, optional+1)
+	default:
+		return nil, layoutError(fields, indexes, optional)
+	}
+
+	f.indexes = indexes
+
+	expanded := make([]string, len(layoutFields))
+	for i, f := range layoutFields {
+		expanded[i] = f.missing
+	}
+
+	for i, j := range indexes {
+		expanded[j] = fields[i]
+	}
+
+	return expanded, nil
+}
+
+// returns the error for an expression whose number of fields doesn't match the layout, listing the fields of the layout
+func layoutError(fields []string, indexes []int, optional int) error {
+	names := make([]string, len(indexes))
+	for i, j := range indexes {
+		names[i] = randomBounds[j].name
+		if i == optional {
+			names[i] = "an optional " + names[i]
+		}
+	}
+
+	reason := fmt.Sprintf("expected %d fields, got %d", len(indexes), len(fields))
+	if optional >= 0 {
+		reason = fmt.Sprintf("expected %d or %d fields, got %d", len(indexes)-1, len(indexes), len(fields))
+	}
+
+	suggestion := "use " + names[0]
+	if len(names) > 1 {
+		suggestion = "use " + strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+	}
+
+	return &ParseError{
+		Token:      strings.Join(fields, " "),
+		Reason:     reason,
+		Suggestion: suggestion,
+	}
+}
+
+// returns an error for the first repeated value or step larger than its range of the fields, from the seconds to the optional year, or if the days of month of the schedule don't exist in any of its months
+//
+// the fields must be the ones of the schedule; the special tokens of the day fields (L, W, #, ?) are not checked
+func checkStrict(c *Cron, fields []string) error {
+	for i, field := range fields {
+		bounds := strictBounds[i]
+		seen := make([]bool, bounds.max-bounds.min+1)
+
+		// 7 is sunday again in the day of week field
+		dow := bounds.name == boundDOW.name
+
+		for _, fieldPart := range strings.Split(field, ",") {
+			partBounds := bounds
+			if dow && openRange(fieldPart) {
+				partBounds = weekBounds(bounds)
+			}
+
+			begin, end, step, err := parseRange(fieldPart, partBounds)
+			if err != nil {
+				continue
+			}
+
+			if step > 1 && begin+step > end {
+				return bounds.syntaxError(fieldPart, fmt.Sprintf("step '%d' is larger than the range %d-%d", step, begin, end), fmt.Sprintf("did you mean %d?", begin))
+			}
+
+			for v := begin; v <= end; v += step {
+				j := v - bounds.min
+				if dow {
+					j = j % 7
+				}
+
+				if seen[j] {
+					return bounds.syntaxError(fieldPart, fmt.Sprintf("'%d' is repeated", v), "")
+				}
+
+				seen[j] = true
+			}
+		}
+	}
+
+	// "L" and "W" match in every month
+	if c.domLast != 0 || c.domWeekday != 0 {
+		return nil
+	}
+
+	for m := time.January; m <= time.December; m++ {
+		// 2000 is a leap year, so february has 29 days
+		if c.month&(1<<m) != 0 && c.dom&(1<<(daysIn(2000, m)+1)-2) != 0 {
+			return nil
+		}
+	}
+
+	return boundDOM.syntaxError(fields[3], fmt.Sprintf("the days '%s' don't exist in the months '%s'", fields[3], fields[4]), "")
+}
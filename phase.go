@@ -0,0 +1,84 @@
+package cron
+
+import (
+	"time"
+)
+
+type (
+	// the position of a time within the interval of the schedule containing it
+	Phase struct {
+		// the occurrence starting the interval, at or before the time
+		Prev time.Time
+		// the occurrence ending the interval, after the time
+		Next time.Time
+		// the time since Prev
+		Elapsed time.Duration
+		// the time until Next
+		Remaining time.Duration
+		// the elapsed part of the interval, from 0 (at Prev) to 1 (at Next)
+		Fraction float64
+	}
+
+	// the interval between two consecutive occurrences of a schedule, from Start (included) to End (excluded)
+	Period struct {
+		Start time.Time
+		End   time.Time
+	}
+)
+
+// returns how far t is into the interval of the schedule containing it; e.g., for progress bars or to score how stale the last run is
+//
+// it returns ErrMaxYearLimit when there is no occurrence at or before t, or after t, within the year limit
+func (s *Cron) Phase(t time.Time) (Phase, error) {
+	period, err := s.PeriodFor(t)
+	if err != nil {
+		return Phase{}, err
+	}
+
+	prev, next := period.Start, period.End
+
+	p := Phase{
+		Prev:      prev,
+		Next:      next,
+		Elapsed:   t.Sub(prev),
+		Remaining: next.Sub(t),
+	}
+
+	p.Fraction = float64(p.Elapsed) / float64(next.Sub(prev))
+
+	return p, nil
+}
+
+// returns the period of the schedule containing t: from the last occurrence at or before t to the first one after it
+//
+// a run at an occurrence starts the period it belongs to; the window it covers is usually the previous one, PeriodFor(occurrence.Add(-time.Nanosecond))
+//
+// it returns ErrMaxYearLimit when there is no occurrence at or before t, or after t, within the year limit
+func (s *Cron) PeriodFor(t time.Time) (Period, error) {
+	start, err := s.Align(t)
+	if err != nil {
+		return Period{}, err
+	}
+
+	end, err := s.Next(t)
+	if err != nil {
+		return Period{}, err
+	}
+
+	return Period{Start: start, End: end}, nil
+}
+
+// returns the period of the schedule containing the current time
+func (s *Cron) CurrentPeriod() (Period, error) {
+	return s.PeriodFor(time.Now())
+}
+
+// reports whether t is within the period
+func (p Period) Contains(t time.Time) bool {
+	return !t.Before(p.Start) && t.Before(p.End)
+}
+
+// returns the length of the period
+func (p Period) Duration() time.Duration {
+	return p.End.Sub(p.Start)
+}
@@ -0,0 +1,265 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type (
+	// limits enforced by ParseUntrusted on expressions supplied by untrusted users. Zero values disable the limit
+	Policy struct {
+		// max length of the expression in bytes
+		MaxLength int
+		// max number of fields of the expression, not counting a "CRON_TZ=" or "TZ=" prefix
+		MaxFields int
+		// max number of comma separated items in a single field
+		MaxListItems int
+		// special characters allowed in the expression (e.g. "*,-"); digits, letters and spaces are always allowed
+		AllowedCharacters string
+		// special tokens allowed in the expression; nil doesn't restrict them and an empty slice allows none, so only numbers and the characters of AllowedCharacters are left
+		AllowedSpecials []Special
+		// min time between two consecutive occurrences
+		MinInterval time.Duration
+		// names of the allowed timezones (as returned by time.Location.String)
+		AllowedLocations []string
+		// rejects the timezones that are not in the IANA database, like fixed offsets and Local
+		IANALocationsOnly bool
+		// rejects the expressions that are valid but probably not what was meant, as WithStrict does
+		Strict bool
+	}
+
+	// a kind of special token that a policy may allow
+	Special int
+
+	// describes why a timezone is not allowed by a policy
+	//
+	// it wraps ErrLocationNotAllowed, so errors.Is(err, ErrLocationNotAllowed) keeps working
+	LocationError struct {
+		// the name of the timezone, as returned by time.Location.String
+		Location string
+		// why the timezone is not allowed
+		Reason string
+	}
+)
+
+const (
+	// "L" in the day of month ("L", "L-3") and day of week ("5L") fields
+	SpecialLast Special = iota
+	// "W" in the day of month field ("15W", "LW")
+	SpecialWeekday
+	// "#" in the day of week field ("FRI#2")
+	SpecialNth
+	// "?" in the day of month and day of week fields
+	SpecialAny
+	// macros like "@daily"
+	SpecialMacro
+	// a "CRON_TZ=" or "TZ=" prefix
+	SpecialZone
+	// names of months and weekdays, like "JAN" or "MON"
+	SpecialName
+)
+
+var (
+	// a policy suitable for most user facing inputs
+	DefaultPolicy = Policy{
+		MaxLength:    256,
+		MaxListItems: 60,
+		MinInterval:  time.Minute,
+		Strict:       true,
+	}
+
+	ErrExpressionTooLong   = errors.New("the expression exceeds the max length")
+	ErrTooManyFields       = errors.New("the expression exceeds the max number of fields")
+	ErrTooManyListItems    = errors.New("a field exceeds the max number of list items")
+	ErrCharacterNotAllowed = errors.New("the expression contains a character that is not allowed")
+	ErrSpecialNotAllowed   = errors.New("the expression contains a special token that is not allowed")
+	ErrIntervalTooShort    = errors.New("the expression runs more often than the min interval")
+	ErrLocationNotAllowed  = errors.New("the timezone is not allowed")
+)
+
+// returns the same result as Parse, but it also enforces the policy on the expression and timezone
+//
+// the timezone is checked after parsing, so a timezone given by a "CRON_TZ=" or "TZ=" prefix must be allowed too
+//
+// it returns ErrInvalidExpression when the syntax of expression is wrong, a *LocationError when the timezone is not allowed, or the policy error the expression violates
+func ParseUntrusted(expr string, tz *time.Location, policy Policy) (*Cron, error) {
+	if policy.MaxLength > 0 && len(expr) > policy.MaxLength {
+		return nil, ErrExpressionTooLong
+	}
+
+	if policy.AllowedCharacters != "" {
+		for _, r := range expr {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsSpace(r) && !strings.ContainsRune(policy.AllowedCharacters, r) {
+				return nil, ErrCharacterNotAllowed
+			}
+		}
+	}
+
+	if err := policy.checkFields(expr); err != nil {
+		return nil, err
+	}
+
+	if policy.MaxListItems > 0 {
+		for _, field := range strings.Fields(expr) {
+			if strings.Count(field, ",")+1 > policy.MaxListItems {
+				return nil, ErrTooManyListItems
+			}
+		}
+	}
+
+	opts := []Option{WithLocation(tz)}
+	if policy.Strict {
+		opts = append(opts, WithStrict())
+	}
+
+	c, err := ParseWithOptions(expr, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// the timezone of the schedule may come from a "CRON_TZ=" prefix of the expression
+	if err := policy.checkLocation(c.tz); err != nil {
+		return nil, err
+	}
+
+	if policy.MinInterval > 0 && c.minInterval() < policy.MinInterval {
+		return nil, ErrIntervalTooShort
+	}
+
+	return c, nil
+}
+
+func (e *LocationError) Error() string {
+	return ErrLocationNotAllowed.Error() + ": " + e.Reason
+}
+
+func (e *LocationError) Unwrap() error {
+	return ErrLocationNotAllowed
+}
+
+// returns ErrTooManyFields or ErrSpecialNotAllowed if the policy doesn't allow the fields of the expression
+func (p Policy) checkFields(expr string) error {
+	fields := strings.Fields(expr)
+
+	var specials []Special
+	if len(fields) > 0 && (strings.HasPrefix(fields[0], "CRON_TZ=") || strings.HasPrefix(fields[0], "TZ=")) {
+		specials = append(specials, SpecialZone)
+		fields = fields[1:]
+	}
+
+	if p.MaxFields > 0 && len(fields) > p.MaxFields {
+		return ErrTooManyFields
+	}
+
+	if p.AllowedSpecials == nil {
+		return nil
+	}
+
+	for _, field := range fields {
+		specials = append(specials, fieldSpecials(field)...)
+	}
+
+	for _, special := range specials {
+		if !slices.Contains(p.AllowedSpecials, special) {
+			return ErrSpecialNotAllowed
+		}
+	}
+
+	return nil
+}
+
+// returns the special tokens used by a field of an expression
+//
+// a run of letters is "L", "W" or "LW", or else the name of a month or weekday, so the "L" of "JUL" is a name and the one of "FRIL" is not
+func fieldSpecials(field string) []Special {
+	if strings.HasPrefix(field, "@") {
+		return []Special{SpecialMacro}
+	}
+
+	var specials []Special
+	if strings.Contains(field, "?") {
+		specials = append(specials, SpecialAny)
+	}
+
+	if strings.Contains(field, "#") {
+		specials = append(specials, SpecialNth)
+	}
+
+	isNotLetter := func(r rune) bool { return !unicode.IsLetter(r) }
+	for _, word := range strings.FieldsFunc(strings.ToUpper(field), isNotLetter) {
+		switch {
+		case word == "L":
+			specials = append(specials, SpecialLast)
+		case word == "W":
+			specials = append(specials, SpecialWeekday)
+		case word == "LW":
+			specials = append(specials, SpecialLast, SpecialWeekday)
+		case strings.HasSuffix(word, "L") && slices.Contains(boundDOW.aliases, word[:len(word)-1]):
+			// a weekday name followed by "L", like "FRIL"
+			specials = append(specials, SpecialName, SpecialLast)
+		default:
+			specials = append(specials, SpecialName)
+		}
+	}
+
+	return specials
+}
+
+// returns a *LocationError if the policy doesn't allow the timezone
+func (p Policy) checkLocation(tz *time.Location) error {
+	if tz == nil {
+		if len(p.AllowedLocations) > 0 || p.IANALocationsOnly {
+			return &LocationError{Reason: "the schedule has no timezone"}
+		}
+
+		return nil
+	}
+
+	name := tz.String()
+
+	if len(p.AllowedLocations) > 0 && !slices.Contains(p.AllowedLocations, name) {
+		return &LocationError{Location: name, Reason: fmt.Sprintf("'%s' is not one of the allowed timezones", name)}
+	}
+
+	if p.IANALocationsOnly {
+		if _, err := time.LoadLocation(name); err != nil || name == "Local" {
+			return &LocationError{Location: name, Reason: fmt.Sprintf("'%s' is not an IANA timezone", name)}
+		}
+	}
+
+	return nil
+}
+
+// returns the shortest time between two consecutive occurrences of the schedule
+//
+// it assumes that consecutive days can match, so the result may be shorter than the real one for schedules restricted to non consecutive days
+func (s *Cron) minInterval() time.Duration {
+	minutes := s.Minutes()
+	hours := s.Hours()
+
+	// the first minute of the next matching hour (or the next day) is the successor of the last minute of an hour
+	shortest := 24 * time.Hour
+	for i, h := range hours {
+		nextHour := int(hours[0]) + 24
+		if i+1 < len(hours) {
+			nextHour = int(hours[i+1])
+		}
+
+		for j, m := range minutes {
+			next := int(minutes[0]) + (nextHour-int(h))*60
+			if j+1 < len(minutes) {
+				next = int(minutes[j+1])
+			}
+
+			if d := time.Duration(next-int(m)) * time.Minute; d < shortest {
+				shortest = d
+			}
+		}
+	}
+
+	return shortest
+}
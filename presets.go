@@ -0,0 +1,115 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// expressions for the fixed frequency helpers of Laravel's scheduler
+	Presets = map[string]string{
+		"everyMinute":         "* * * * *",
+		"everyTwoMinutes":     "*/2 * * * *",
+		"everyThreeMinutes":   "*/3 * * * *",
+		"everyFourMinutes":    "*/4 * * * *",
+		"everyFiveMinutes":    "*/5 * * * *",
+		"everyTenMinutes":     "*/10 * * * *",
+		"everyFifteenMinutes": "*/15 * * * *",
+		"everyThirtyMinutes":  "0,30 * * * *",
+		"hourly":              "0 * * * *",
+		"everyTwoHours":       "0 */2 * * *",
+		"everyThreeHours":     "0 */3 * * *",
+		"everyFourHours":      "0 */4 * * *",
+		"everySixHours":       "0 */6 * * *",
+		"daily":               "0 0 * * *",
+		"weekdays":            "0 0 * * 1-5",
+		"weekends":            "0 0 * * 0,6",
+		"weekly":              "0 0 * * 0",
+		"monthly":             "0 0 1 * *",
+		"quarterly":           "0 0 1 1,4,7,10 *",
+		"yearly":              "0 0 1 1 *",
+	}
+
+	ErrUnknownPreset   = errors.New("unknown preset")
+	ErrInvalidTime     = errors.New("invalid time of day")
+	ErrUnsupportedRate = errors.New("the rate can not be represented as a cron expression")
+)
+
+// returns the schedule for the given preset name (see Presets)
+func Preset(name string, tz *time.Location) (*Cron, error) {
+	expr, ok := Presets[name]
+	if !ok {
+		return nil, ErrUnknownPreset
+	}
+
+	return Parse(expr, tz)
+}
+
+// returns a schedule running every day at the given time ("13:00"), like Laravel's dailyAt
+func DailyAt(timeOfDay string, tz *time.Location) (*Cron, error) {
+	hour, minute, err := parseTimeOfDay(timeOfDay)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(fmt.Sprintf("%d %d * * *", minute, hour), tz)
+}
+
+// returns a schedule running every week on the given day at the given time ("8:00"), like Laravel's weeklyOn
+func WeeklyOn(day time.Weekday, timeOfDay string, tz *time.Location) (*Cron, error) {
+	hour, minute, err := parseTimeOfDay(timeOfDay)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(fmt.Sprintf("%d %d * * %d", minute, hour, day), tz)
+}
+
+// returns a schedule running every month on the given day at the given time ("15:00"), like Laravel's monthlyOn
+func MonthlyOn(day int, timeOfDay string, tz *time.Location) (*Cron, error) {
+	hour, minute, err := parseTimeOfDay(timeOfDay)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(fmt.Sprintf("%d %d %d * *", minute, hour, day), tz)
+}
+
+// returns a schedule running at a fixed rate, like Spring's @Scheduled(fixedRate)
+//
+// the rate is aligned to the clock (e.g. 15 minutes runs at :00, :15, :30 and :45), so only whole minutes dividing an hour, whole hours dividing a day, or a day are supported; other rates return ErrUnsupportedRate
+func FixedRate(rate time.Duration, tz *time.Location) (*Cron, error) {
+	switch {
+	case rate == 24*time.Hour:
+		return Parse("0 0 * * *", tz)
+	case rate >= time.Hour && rate%time.Hour == 0 && 24%int(rate/time.Hour) == 0:
+		return Parse(fmt.Sprintf("0 */%d * * *", rate/time.Hour), tz)
+	case rate >= time.Minute && rate%time.Minute == 0 && 60%int(rate/time.Minute) == 0:
+		return Parse(fmt.Sprintf("*/%d * * * *", rate/time.Minute), tz)
+	}
+
+	return nil, ErrUnsupportedRate
+}
+
+// returns the hour and minute of a "hh:mm" time of day
+func parseTimeOfDay(timeOfDay string) (int, int, error) {
+	hourAndMinute := strings.Split(timeOfDay, ":")
+	if len(hourAndMinute) != 2 {
+		return 0, 0, ErrInvalidTime
+	}
+
+	hour, err := strconv.Atoi(hourAndMinute[0])
+	if err != nil || !boundHour.contains(hour) {
+		return 0, 0, ErrInvalidTime
+	}
+
+	minute, err := strconv.Atoi(hourAndMinute[1])
+	if err != nil || !boundMinute.contains(minute) {
+		return 0, 0, ErrInvalidTime
+	}
+
+	return hour, minute, nil
+}
@@ -0,0 +1,75 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	// Quartz numbers the weekdays from 1 (sunday) to 7 (saturday)
+	boundQuartzDOW = fieldBounds{1, 7, boundDOW.name, boundDOW.aliases}
+)
+
+// parses a Quartz expression and returns a new schedule representing the given spec
+//
+// the expression has the Quartz fields: seconds, minutes, hours, day of month, month, day of week (1-7 or SUN-SAT, 1 is sunday) and an optional year. As in Quartz, exactly one of the day of month and day of week fields must be "?", and "L" alone in the day of week field is saturday
+//
+// it returns an error when the syntax of expression is wrong
+func ParseQuartz(expr string, tz *time.Location) (*Cron, error) {
+	if err := checkLength(expr); err != nil {
+		return nil, err
+	}
+
+	// the byte offset of the trimmed expression in the given one
+	offset := len(expr) - len(strings.TrimLeftFunc(expr, unicode.IsSpace))
+
+	expr = strings.TrimSpace(expr)
+
+	f := splitFields(expr, offset)
+
+	fields := f.fields
+	if len(fields) != 6 && len(fields) != 7 {
+		return nil, &ParseError{
+			Token:      expr,
+			Offset:     offset,
+			Reason:     fmt.Sprintf("expected 6 or 7 fields, got %d", len(fields)),
+			Suggestion: "use second, minute, hour, day of month, month, day of week and an optional year",
+		}
+	}
+
+	if (fields[3] == "?") == (fields[5] == "?") {
+		return nil, &ParseError{
+			Token:      fields[3] + " " + fields[5],
+			Offset:     f.offsets[3],
+			Reason:     "exactly one of the day of month and day of week fields must be '?'",
+			Suggestion: "use '?' in the field that shouldn't restrict the days",
+		}
+	}
+
+	// the fields are the ones of layoutFields, from the seconds
+	f.indexes = []int{0, 1, 2, 3, 4, 5, 6}[:len(fields)]
+
+	// the last day of the week
+	if strings.EqualFold(fields[5], "L") {
+		fields[5] = "7"
+	}
+
+	c, err := parseFields(fields, boundQuartzDOW, tz)
+	if err != nil {
+		return nil, f.locate(err)
+	}
+
+	return c, nil
+}
+
+// returns the same result as ParseQuartz, but it panics when the syntax of expression is wrong
+func MustParseQuartz(expr string, tz *time.Location) *Cron {
+	c, err := ParseQuartz(expr, tz)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
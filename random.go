@@ -0,0 +1,80 @@
+package cron
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// the bounds used to pick random values for the fields, from the seconds to the year; sunday is only picked as 0
+	randomBounds = []fieldBounds{boundSecond, boundMinute, boundHour, boundDOM, boundMonth, boundDOW, boundYear}
+)
+
+// returns the same result as Parse, but it picks the random values of "~" ranges with the given source, so the result is reproducible
+func ParseWithRand(expr string, tz *time.Location, r *rand.Rand) (*Cron, error) {
+	return NewParser(WithLocation(tz), WithRand(r)).Parse(expr)
+}
+
+// replaces the OpenBSD style random ranges of the fields, with the given bounds, with a value picked at random
+//
+// "a~b" is a random value between a and b, inclusive; either end may be omitted to use the bounds of the field, so "~" alone is any value of the field. The source is the global one when r is nil
+func resolveRandom(fields []string, layout []fieldBounds, r *rand.Rand) error {
+	for i := 0; i < len(fields) && i < len(layout); i++ {
+		if !strings.Contains(fields[i], "~") {
+			continue
+		}
+
+		bounds := layout[i]
+
+		parts := strings.Split(fields[i], ",")
+		for j, part := range parts {
+			low, high, ok := strings.Cut(part, "~")
+			if !ok {
+				continue
+			}
+
+			begin, end := bounds.min, bounds.max
+
+			var err error
+			if low != "" {
+				if begin, err = bounds.value(low); err != nil {
+					return bounds.notANumberError(part, low)
+				}
+			}
+
+			if high != "" {
+				if end, err = bounds.value(high); err != nil {
+					return bounds.notANumberError(part, high)
+				}
+			}
+
+			if !bounds.contains(begin) {
+				return bounds.outOfRangeError(part, begin)
+			}
+
+			if !bounds.contains(end) {
+				return bounds.outOfRangeError(part, end)
+			}
+
+			if end < begin {
+				return bounds.syntaxError(part, fmt.Sprintf("random range '%s' is reversed", part), fmt.Sprintf("did you mean %d~%d?", end, begin))
+			}
+
+			var v int
+			if r != nil {
+				v = begin + r.Intn(end-begin+1)
+			} else {
+				v = begin + rand.Intn(end-begin+1)
+			}
+
+			parts[j] = strconv.Itoa(v)
+		}
+
+		fields[i] = strings.Join(parts, ",")
+	}
+
+	return nil
+}
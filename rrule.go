@@ -0,0 +1,466 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUnsupportedRule = errors.New("the recurrence rule can not be represented as a cron expression")
+
+	// the frequencies of the recurrence rules, from the shortest; the first three are the second, minute and hour fields
+	rruleFreqs = []string{"SECONDLY", "MINUTELY", "HOURLY", "DAILY", "WEEKLY", "MONTHLY", "YEARLY"}
+
+	// the weekdays of the recurrence rules, indexed from sunday
+	rruleWeekdays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+)
+
+const (
+	rruleDaily = 3 + iota
+	rruleWeekly
+	rruleMonthly
+	rruleYearly
+)
+
+// converts an iCalendar (RFC 5545) recurrence rule into a schedule; e.g., "FREQ=WEEKLY;BYDAY=MO,WE;BYHOUR=9;BYMINUTE=0"
+//
+// the rule may be prefixed with "RRULE:" and preceded by a DTSTART line ("DTSTART;TZID=Europe/Madrid:20240101T090000"), which gives the timezone and the values of the parts missing in the rule, as in RFC 5545 (e.g. the time of day of a daily rule). Without DTSTART the time of day is midnight in tz. Like in FromTaskTrigger, the occurrences before DTSTART are not filtered
+//
+// it returns ErrUnsupportedRule for the rules without an equivalent cron expression (COUNT, UNTIL, BYSETPOS, BYYEARDAY, BYWEEKNO, intervals of days or weeks, and intervals not dividing the minute, hour or year), and an error wrapping ErrInvalidExpression when the syntax of rule is wrong
+func FromRRule(rule string, tz *time.Location) (*Cron, error) {
+	if err := checkLength(rule); err != nil {
+		return nil, err
+	}
+
+	var (
+		start    time.Time
+		hasStart bool
+		parts    = map[string]string{}
+	)
+
+	for _, line := range strings.FieldsFunc(rule, func(r rune) bool { return r == '\n' || r == '\r' }) {
+		line = strings.TrimSpace(line)
+		upper := strings.ToUpper(line)
+
+		if strings.HasPrefix(upper, "DTSTART") {
+			var err error
+			if start, err = parseDTStart(line, tz); err != nil {
+				return nil, err
+			}
+
+			hasStart = true
+			continue
+		}
+
+		for _, part := range strings.Split(strings.TrimPrefix(upper, "RRULE:"), ";") {
+			name, value, ok := strings.Cut(part, "=")
+			if _, dup := parts[name]; !ok || dup {
+				return nil, &ParseError{
+					Token:      part,
+					Reason:     fmt.Sprintf("'%s' is not a NAME=VALUE part or is repeated", part),
+					Suggestion: "use FREQ=...;BYDAY=...",
+				}
+			}
+
+			parts[name] = value
+		}
+	}
+
+	if hasStart {
+		tz = start.Location()
+	}
+
+	freq := slices.Index(rruleFreqs, parts["FREQ"])
+	if freq < 0 {
+		return nil, &ParseError{
+			Token:      parts["FREQ"],
+			Reason:     fmt.Sprintf("'%s' is not a frequency", parts["FREQ"]),
+			Suggestion: "use " + strings.Join(rruleFreqs, ", "),
+		}
+	}
+
+	interval := 1
+	for name, value := range parts {
+		switch name {
+		case "FREQ", "BYSECOND", "BYMINUTE", "BYHOUR", "BYDAY", "BYMONTHDAY", "BYMONTH", "WKST":
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, &ParseError{Token: value, Reason: fmt.Sprintf("interval '%s' is not a positive number", value)}
+			}
+
+			interval = n
+		case "COUNT", "UNTIL", "BYSETPOS", "BYYEARDAY", "BYWEEKNO":
+			return nil, ErrUnsupportedRule
+		default:
+			return nil, &ParseError{Token: name, Reason: fmt.Sprintf("unknown rule part '%s'", name)}
+		}
+	}
+
+	// second, minute, hour, day of month, month, day of week and year
+	fields := []string{"*", "*", "*", "*", "*", "*", "*"}
+
+	// the parts shorter than the frequency come from DTSTART, and the part of the frequency repeats every interval
+	for i, by := range []string{"BYSECOND", "BYMINUTE", "BYHOUR"} {
+		bounds := []fieldBounds{boundSecond, boundMinute, boundHour}[i]
+		value := []int{start.Second(), start.Minute(), start.Hour()}[i]
+
+		switch list, ok := parts[by]; {
+		case ok && i == freq && interval > 1:
+			return nil, ErrUnsupportedRule
+		case ok:
+			values, err := rruleValues(by, list, bounds)
+			if err != nil {
+				return nil, err
+			}
+
+			fields[i] = values
+		case i < freq:
+			fields[i] = strconv.Itoa(value)
+		case i == freq && interval > 1:
+			if (bounds.max+1)%interval != 0 {
+				return nil, ErrUnsupportedRule
+			}
+
+			fields[i] = fmt.Sprintf("%d-%d/%d", value%interval, bounds.max, interval)
+		}
+	}
+
+	_, hasDOM := parts["BYMONTHDAY"]
+	_, hasDOW := parts["BYDAY"]
+	_, hasMonth := parts["BYMONTH"]
+
+	switch {
+	case freq == rruleDaily && interval > 1, freq == rruleWeekly && interval > 1:
+		return nil, ErrUnsupportedRule
+	case freq == rruleMonthly && interval > 1:
+		if hasMonth || 12%interval != 0 {
+			return nil, ErrUnsupportedRule
+		}
+
+		fields[4] = fmt.Sprintf("%d-12/%d", (int(start.Month())-1)%interval+1, interval)
+	case freq == rruleYearly && interval > 1:
+		year := boundYear.min
+		if hasStart {
+			year = max(start.Year(), boundYear.min)
+		}
+
+		fields[6] = fmt.Sprintf("%d-%d/%d", year, boundYear.max, interval)
+	}
+
+	// the days missing in weekly, monthly and yearly rules are the ones of DTSTART
+	defaults := []bool{
+		(freq == rruleMonthly || freq == rruleYearly) && !hasDOM && !hasDOW,
+		freq == rruleYearly && !hasMonth && !hasDOM && !hasDOW,
+		freq == rruleWeekly && !hasDOW,
+	}
+
+	for i, value := range []int{start.Day(), int(start.Month()), int(start.Weekday())} {
+		if !defaults[i] {
+			continue
+		}
+
+		if !hasStart {
+			return nil, &ParseError{
+				Token:      rruleFreqs[freq],
+				Reason:     fmt.Sprintf("a %s rule without BYDAY or BYMONTHDAY needs a DTSTART", strings.ToLower(rruleFreqs[freq])),
+				Suggestion: "add the days to the rule",
+			}
+		}
+
+		fields[i+3] = strconv.Itoa(value)
+	}
+
+	if list, ok := parts["BYMONTH"]; ok {
+		values, err := rruleValues("BYMONTH", list, boundMonth)
+		if err != nil {
+			return nil, err
+		}
+
+		fields[4] = values
+	}
+
+	if list, ok := parts["BYMONTHDAY"]; ok {
+		days, err := rruleMonthDays(list)
+		if err != nil {
+			return nil, err
+		}
+
+		fields[3] = days
+	}
+
+	if list, ok := parts["BYDAY"]; ok {
+		// the ordinals count the weekdays in the month only in monthly rules and yearly rules by month
+		ordinals := freq == rruleMonthly || freq == rruleYearly && hasMonth
+
+		days, err := rruleDays(list, ordinals)
+		if err != nil {
+			return nil, err
+		}
+
+		fields[5] = days
+	}
+
+	return parseFields(fields, boundDOWInput, tz)
+}
+
+// returns the same result as FromRRule, but it panics when the rule can't be converted
+func MustFromRRule(rule string, tz *time.Location) *Cron {
+	c, err := FromRRule(rule, tz)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+// returns the start time of a DTSTART line, in UTC ("...Z"), its TZID or tz
+func parseDTStart(line string, tz *time.Location) (time.Time, error) {
+	head, value, _ := strings.Cut(line, ":")
+
+	params := strings.Split(head, ";")
+	for _, param := range params[1:] {
+		name, zone, _ := strings.Cut(param, "=")
+		if !strings.EqualFold(name, "TZID") {
+			continue
+		}
+
+		loc, err := SystemZones.LoadLocation(zone)
+		if err != nil {
+			return time.Time{}, &ParseError{
+				Field:  "timezone",
+				Token:  zone,
+				Reason: fmt.Sprintf("unknown timezone '%s'", zone),
+			}
+		}
+
+		tz = loc
+	}
+
+	layout := "20060102T150405"
+	switch {
+	case strings.HasSuffix(value, "Z"):
+		value, tz = strings.TrimSuffix(value, "Z"), time.UTC
+	case len(value) == len("20060102"):
+		layout = "20060102"
+	}
+
+	start, err := time.ParseInLocation(layout, value, tz)
+	if err != nil {
+		return time.Time{}, &ParseError{
+			Token:      value,
+			Reason:     fmt.Sprintf("'%s' is not a valid start time", value),
+			Suggestion: "use YYYYMMDDThhmmss",
+		}
+	}
+
+	return start, nil
+}
+
+// returns the field expression of a list of numbers within the bounds
+func rruleValues(name, list string, bounds fieldBounds) (string, error) {
+	var values []int
+
+	for _, item := range strings.Split(list, ",") {
+		v, err := strconv.Atoi(item)
+		if err != nil {
+			return "", &ParseError{Token: item, Reason: fmt.Sprintf("%s value '%s' is not a number", name, item)}
+		}
+
+		if !bounds.contains(v) {
+			return "", bounds.outOfRangeError(item, v)
+		}
+
+		values = append(values, v)
+	}
+
+	slices.Sort(values)
+
+	return formatValues(slices.Compact(values)), nil
+}
+
+// returns the day of month field expression of a BYMONTHDAY list, whose negative days are counted back from the last day of the month
+func rruleMonthDays(list string) (string, error) {
+	var days []string
+
+	for _, item := range strings.Split(list, ",") {
+		d, err := strconv.Atoi(item)
+		if err != nil || d == 0 || d < -boundDOM.max || d > boundDOM.max {
+			return "", boundDOM.syntaxError(item, fmt.Sprintf("BYMONTHDAY value '%s' is not a day from 1 to 31 or -31 to -1", item), "")
+		}
+
+		switch {
+		case d == -1:
+			days = append(days, "L")
+		case d < 0:
+			days = append(days, "L-"+strconv.Itoa(-d-1))
+		default:
+			days = append(days, strconv.Itoa(d))
+		}
+	}
+
+	return strings.Join(days, ","), nil
+}
+
+// returns the day of week field expression of a BYDAY list; the weekdays prefixed with 1 to 5 or -1 are their nth or last occurrence in the month
+func rruleDays(list string, ordinals bool) (string, error) {
+	var days []string
+
+	for _, item := range strings.Split(list, ",") {
+		if len(item) < 2 {
+			return "", boundDOW.syntaxError(item, fmt.Sprintf("BYDAY value '%s' is not a weekday", item), "use SU, MO, TU, WE, TH, FR or SA")
+		}
+
+		prefix, name := item[:len(item)-2], item[len(item)-2:]
+
+		w := slices.Index(rruleWeekdays, name)
+		if w < 0 {
+			return "", boundDOW.syntaxError(item, fmt.Sprintf("BYDAY value '%s' is not a weekday", item), "use SU, MO, TU, WE, TH, FR or SA")
+		}
+
+		if prefix == "" {
+			days = append(days, strconv.Itoa(w))
+			continue
+		}
+
+		n, err := strconv.Atoi(prefix)
+		if err != nil {
+			return "", boundDOW.syntaxError(item, fmt.Sprintf("'%s' is not an ordinal", prefix), "")
+		}
+
+		switch {
+		case !ordinals:
+			return "", ErrUnsupportedRule
+		case n == -1:
+			days = append(days, strconv.Itoa(w)+"L")
+		case n >= 1 && n <= 5:
+			days = append(days, strconv.Itoa(w)+"#"+strconv.Itoa(n))
+		default:
+			return "", ErrUnsupportedRule
+		}
+	}
+
+	return strings.Join(days, ","), nil
+}
+
+// returns the schedule as an iCalendar (RFC 5545) recurrence rule, without the "RRULE:" prefix, and the parts of the schedule that the rule can't represent
+//
+// the rule has no timezone, so it must be used with a DTSTART in the timezone of the schedule. The nearest weekdays ("15W", "LW") and the years are dropped and reported as losses
+func (s *Cron) RRule() (string, []string) {
+	var (
+		parts  []string
+		losses []string
+	)
+
+	// the frequency is the one of the shortest field matching every value, and the fields shorter than the frequency are listed
+	times := []struct {
+		name   string
+		values []int
+		all    bool
+	}{
+		{"BYSECOND", bitsetValues[bitset64, int](s.second, boundSecond), s.second == buildBitset[bitset64](boundSecond.min, boundSecond.max, 1)},
+		{"BYMINUTE", bitsetValues[bitset64, int](s.minute, boundMinute), s.minute == buildBitset[bitset64](boundMinute.min, boundMinute.max, 1)},
+		{"BYHOUR", bitsetValues[bitset32, int](s.hour, boundHour), s.hour == buildBitset[bitset32](boundHour.min, boundHour.max, 1)},
+	}
+
+	freq := rruleDaily
+	for i, field := range times {
+		if field.all {
+			freq = i
+			break
+		}
+	}
+
+	var days []string
+	for _, d := range bitsetValues[bitset32, int](s.dom, boundDOM) {
+		days = append(days, strconv.Itoa(d))
+	}
+
+	for n := 0; n < boundDOM.max; n++ {
+		if s.domLast&(1<<n) != 0 {
+			days = append(days, strconv.Itoa(-n-1))
+		}
+	}
+
+	if s.domWeekday != 0 {
+		losses = append(losses, "the nearest weekdays of the day of month field")
+	}
+
+	if s.dom == buildBitset[bitset32](boundDOM.min, boundDOM.max, 1) {
+		days = nil
+	}
+
+	var weekdays []string
+	ordinals := s.dowLast != 0
+	for w := boundDOW.min; w <= boundDOW.max; w++ {
+		if s.dow&(1<<w) != 0 {
+			weekdays = append(weekdays, rruleWeekdays[w])
+		}
+
+		for n := 1; n <= 5; n++ {
+			if s.dowNth[w]&(1<<n) != 0 {
+				weekdays = append(weekdays, strconv.Itoa(n)+rruleWeekdays[w])
+				ordinals = true
+			}
+		}
+
+		if s.dowLast&(1<<w) != 0 {
+			weekdays = append(weekdays, "-1"+rruleWeekdays[w])
+		}
+	}
+
+	if s.dow == buildBitset[bitset8](boundDOW.min, boundDOW.max, 1) {
+		weekdays = nil
+	}
+
+	// the ordinals of the weekdays are only valid in monthly rules, and the days of month are not valid in weekly ones
+	switch {
+	case ordinals:
+		freq = rruleMonthly
+	case freq < rruleDaily:
+	case len(days) > 0:
+		freq = rruleMonthly
+	case len(weekdays) > 0:
+		freq = rruleWeekly
+	}
+
+	parts = append(parts, "FREQ="+rruleFreqs[freq])
+
+	if s.month != buildBitset[bitset16](boundMonth.min, boundMonth.max, 1) {
+		parts = append(parts, "BYMONTH="+joinInts(bitsetValues[bitset16, int](s.month, boundMonth)))
+	}
+
+	if len(days) > 0 {
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+
+	if len(weekdays) > 0 {
+		parts = append(parts, "BYDAY="+strings.Join(weekdays, ","))
+	}
+
+	for i := len(times) - 1; i >= 0; i-- {
+		if i < freq || !times[i].all {
+			parts = append(parts, times[i].name+"="+joinInts(times[i].values))
+		}
+	}
+
+	if s.year != nil {
+		losses = append(losses, "the years "+formatValues(s.year))
+	}
+
+	return strings.Join(parts, ";"), losses
+}
+
+// returns the numbers as a comma separated list
+func joinInts(values []int) string {
+	items := make([]string, len(values))
+	for i, v := range values {
+		items[i] = strconv.Itoa(v)
+	}
+
+	return strings.Join(items, ",")
+}
@@ -0,0 +1,248 @@
+package cron
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+type (
+	// calculates the occurrences of a schedule; implemented by Cron and by the schedules combining them
+	Schedule interface {
+		// returns the first occurrence after t, or ErrMaxYearLimit if there is none
+		Next(t time.Time) (time.Time, error)
+	}
+
+	// a schedule whose occurrences are the union of the occurrences of its schedules
+	UnionSchedule []Schedule
+
+	// a schedule whose occurrences are the ones of Schedule that are not occurrences of Except
+	ExceptSchedule struct {
+		Schedule Schedule
+		Except   Schedule
+	}
+
+	// a schedule running every fixed interval, phase locked to an anchor time
+	IntervalSchedule struct {
+		interval time.Duration
+		anchor   time.Time
+	}
+)
+
+var (
+	ErrInvalidInterval = errors.New("the interval must be positive")
+)
+
+// returns the same result as ParseSchedule, but it panics when the syntax of any of the expressions is wrong
+func MustParseSchedule(expr string, tz *time.Location) Schedule {
+	s, err := ParseSchedule(expr, tz)
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// parses one or more expressions separated by ";" or newlines and returns the schedule representing all of them
+//
+// a single expression returns a *Cron, several return a UnionSchedule; e.g., "0 9 * * 1-5; 0 10 * * 6"
+//
+// expressions prefixed with "!", or following "except" or "except:" in the same line, are exclusions: their occurrences are subtracted from the others in an ExceptSchedule; e.g., "0 * * * * except: 0 2-4 * * *" or "0 9 * * * except 0 9 * * SUN"
+//
+// it returns an error when the syntax of any of the expressions is wrong
+func ParseSchedule(expr string, tz *time.Location) (Schedule, error) {
+	var schedules, exclusions UnionSchedule
+
+	for _, line := range strings.FieldsFunc(expr, func(r rune) bool { return r == ';' || r == '\n' }) {
+		include, exclude := cutExcept(line)
+
+		include = strings.TrimSpace(include)
+		exclude = strings.TrimSpace(exclude)
+
+		if strings.HasPrefix(include, "!") {
+			include, exclude = "", strings.TrimSpace(include[1:])
+		}
+
+		if include != "" {
+			c, err := Parse(include, tz)
+			if err != nil {
+				return nil, err
+			}
+
+			schedules = append(schedules, c)
+		}
+
+		if exclude != "" {
+			c, err := Parse(exclude, tz)
+			if err != nil {
+				return nil, err
+			}
+
+			exclusions = append(exclusions, c)
+		}
+	}
+
+	if len(schedules) == 0 {
+		return nil, &ParseError{Token: expr, Reason: "the expression has no schedule to include"}
+	}
+
+	if len(exclusions) == 0 {
+		return schedules.simplify(), nil
+	}
+
+	return ExceptSchedule{Schedule: schedules.simplify(), Except: exclusions.simplify()}, nil
+}
+
+// returns the same result as ParseMulti, but it panics when the syntax of any of the expressions is wrong
+func MustParseMulti(exprs []string, tz *time.Location) Schedule {
+	s, err := ParseMulti(exprs, tz)
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// parses the expressions and returns the schedule whose occurrences are the union of all of them; e.g., "0 9 * * 1-5" and "0 7 1 * *"
+//
+// a single expression returns a *Cron, several return a UnionSchedule
+//
+// it returns an error when there are no expressions or the syntax of any of them is wrong
+func ParseMulti(exprs []string, tz *time.Location) (Schedule, error) {
+	if len(exprs) == 0 {
+		return nil, &ParseError{Reason: "there are no expressions"}
+	}
+
+	schedules := make(UnionSchedule, 0, len(exprs))
+	for _, expr := range exprs {
+		c, err := Parse(expr, tz)
+		if err != nil {
+			return nil, err
+		}
+
+		schedules = append(schedules, c)
+	}
+
+	return schedules.simplify(), nil
+}
+
+// splits a line into the expression to include and the one following "except:" or the word "except", if any
+func cutExcept(line string) (string, string) {
+	if include, exclude, ok := strings.Cut(line, "except:"); ok {
+		return include, exclude
+	}
+
+	fields := strings.Fields(line)
+	if i := slices.Index(fields, "except"); i >= 0 {
+		return strings.Join(fields[:i], " "), strings.Join(fields[i+1:], " ")
+	}
+
+	return line, ""
+}
+
+// returns the only schedule of the union, or the union itself if it has more than one
+func (u UnionSchedule) simplify() Schedule {
+	if len(u) == 1 {
+		return u[0]
+	}
+
+	return u
+}
+
+// returns the earliest occurrence after t among the schedules
+//
+// schedules without more occurrences are ignored; it returns ErrMaxYearLimit only when none of them has a next occurrence
+func (u UnionSchedule) Next(t time.Time) (time.Time, error) {
+	var next time.Time
+
+	for _, s := range u {
+		n, err := s.Next(t)
+		if errors.Is(err, ErrMaxYearLimit) {
+			continue
+		}
+
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if next.IsZero() || n.Before(next) {
+			next = n
+		}
+	}
+
+	if next.IsZero() {
+		return time.Time{}, ErrMaxYearLimit
+	}
+
+	return next, nil
+}
+
+// returns a schedule with the occurrences of s that are not occurrences of except; e.g., to skip blackout periods
+func (s *Cron) Except(except Schedule) ExceptSchedule {
+	return ExceptSchedule{Schedule: s, Except: except}
+}
+
+// returns the first occurrence of the schedule after t that is not an occurrence of the exception
+//
+// it returns ErrMaxYearLimit if every occurrence within the year limit is excluded
+func (e ExceptSchedule) Next(t time.Time) (time.Time, error) {
+	maxYear := t.Year() + yearLimit
+
+	for {
+		next, err := e.Schedule.Next(t)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if next.Year() > maxYear {
+			return time.Time{}, ErrMaxYearLimit
+		}
+
+		if !isOccurrence(e.Except, next) {
+			return next, nil
+		}
+
+		t = next
+	}
+}
+
+// reports whether t is an occurrence of the schedule
+func isOccurrence(s Schedule, t time.Time) bool {
+	next, err := nextFrom(s, t)
+
+	return err == nil && next.Equal(t)
+}
+
+// returns the first occurrence of the schedule at or after t
+//
+// Next returns the occurrences after the reference, so it starts just before t to include it
+func nextFrom(s Schedule, t time.Time) (time.Time, error) {
+	return s.Next(t.Add(-time.Nanosecond))
+}
+
+// returns a schedule whose occurrences are anchor + n * interval, for any integer n
+//
+// the occurrences don't depend on when Next is called, so restarting the caller doesn't make the schedule drift. The interval is an absolute duration: "every 3 days" keeps 72 hours between occurrences across DST changes
+//
+// it returns ErrInvalidInterval if the interval is not positive
+func Every(interval time.Duration, anchor time.Time) (IntervalSchedule, error) {
+	if interval <= 0 {
+		return IntervalSchedule{}, ErrInvalidInterval
+	}
+
+	return IntervalSchedule{interval: interval, anchor: anchor}, nil
+}
+
+// returns the first occurrence after t
+func (i IntervalSchedule) Next(t time.Time) (time.Time, error) {
+	elapsed := t.Sub(i.anchor)
+
+	// the number of whole intervals from the anchor to t, rounded down (also for times before the anchor)
+	n := elapsed / i.interval
+	if elapsed < 0 && elapsed%i.interval != 0 {
+		n--
+	}
+
+	return i.anchor.Add((n + 1) * i.interval), nil
+}
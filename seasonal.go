@@ -0,0 +1,49 @@
+package cron
+
+import (
+	"errors"
+	"time"
+)
+
+type (
+	// a schedule whose occurrences are the ones of Schedule shifted by the offset of their month; e.g., to run earlier in summer following the daylight
+	SeasonalSchedule struct {
+		Schedule Schedule
+		// the offset of the occurrences in each month, in the timezone of the occurrences; months without offset are not shifted
+		Offsets map[time.Month]time.Duration
+	}
+)
+
+// returns the first shifted occurrence after t
+//
+// the offsets may reorder the occurrences around the month boundaries, so it checks every occurrence that can be shifted to the first one after t
+func (s SeasonalSchedule) Next(t time.Time) (time.Time, error) {
+	// an occurrence can't be shifted further than the largest offset
+	var span time.Duration
+	for _, offset := range s.Offsets {
+		span = max(span, offset, -offset)
+	}
+
+	var first time.Time
+
+	for next := t.Add(-span); ; {
+		var err error
+		next, err = s.Schedule.Next(next)
+		if errors.Is(err, ErrMaxYearLimit) && !first.IsZero() {
+			return first, nil
+		}
+
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		// the occurrences after this one are shifted after the first one found
+		if !first.IsZero() && next.Add(-span).After(first) {
+			return first, nil
+		}
+
+		if shifted := next.Add(s.Offsets[next.Month()]); shifted.After(t) && (first.IsZero() || shifted.Before(first)) {
+			first = shifted
+		}
+	}
+}
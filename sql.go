@@ -0,0 +1,143 @@
+//go:build !cron_nosql
+
+package cron
+
+import (
+	"database/sql/driver"
+	"errors"
+	"time"
+)
+
+type (
+	// a schedule that may be NULL, like sql.NullString; it implements driver.Valuer and sql.Scanner for nullable columns, since Cron rejects NULL
+	NullCron struct {
+		Cron Cron
+		// whether Cron is not NULL
+		Valid bool
+	}
+
+	// the expression column of a schedule stored in two columns, see Columns
+	ExpressionColumn struct {
+		c *Cron
+	}
+
+	// the timezone column of a schedule stored in two columns, see Columns
+	LocationColumn struct {
+		c *Cron
+	}
+)
+
+var (
+	ErrUnsupportedScanType = errors.New("unsupported type for a cron expression column")
+)
+
+// implements driver.Valuer, storing the schedule as the text of MarshalText, with a "CRON_TZ=" prefix for its timezone
+//
+// drivers like pgx use it for text/varchar columns, so no driver specific codec is needed
+func (s *Cron) Value() (driver.Value, error) {
+	text, err := s.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	return string(text), nil
+}
+
+// implements sql.Scanner, parsing a text expression column into the schedule
+//
+// the timezone is taken from the "CRON_TZ=" or "TZ=" prefix written by Value; columns without one keep the timezone already set in the receiver, or UTC if there is none. NULL returns ErrUnsupportedScanType; nullable columns are scanned into a NullCron
+func (s *Cron) Scan(src any) error {
+	expr, err := scanText(src)
+	if err != nil {
+		return err
+	}
+
+	return s.parseText(expr)
+}
+
+// returns the columns of the schedule for tables that store the expression and the timezone apart; both implement driver.Valuer and sql.Scanner, so they are passed as they are to Exec and Scan
+//
+//	expr, tz := c.Columns()
+//	db.Exec("INSERT INTO jobs (expr, tz) VALUES ($1, $2)", expr, tz)
+//	db.QueryRow("SELECT expr, tz FROM jobs").Scan(expr, tz)
+func (s *Cron) Columns() (*ExpressionColumn, *LocationColumn) {
+	return &ExpressionColumn{s}, &LocationColumn{s}
+}
+
+// implements driver.Valuer, storing NULL when the schedule is not valid
+func (n NullCron) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return n.Cron.Value()
+}
+
+// implements sql.Scanner, setting Valid to false for NULL
+func (n *NullCron) Scan(src any) error {
+	if src == nil {
+		n.Cron, n.Valid = Cron{}, false
+		return nil
+	}
+
+	if err := n.Cron.Scan(src); err != nil {
+		n.Valid = false
+		return err
+	}
+
+	n.Valid = true
+
+	return nil
+}
+
+// implements driver.Valuer, storing the expression without a timezone prefix
+func (e *ExpressionColumn) Value() (driver.Value, error) {
+	return e.c.expression(), nil
+}
+
+// implements sql.Scanner, parsing the expression and keeping the timezone, so the columns are scanned in any order
+func (e *ExpressionColumn) Scan(src any) error {
+	return e.c.Scan(src)
+}
+
+// implements driver.Valuer, storing the name of the timezone, or UTC if there is none
+//
+// it returns ErrUnsupportedZone for the fixed offsets that can't be written, like MarshalText
+func (l *LocationColumn) Value() (driver.Value, error) {
+	if l.c.tz == nil {
+		return time.UTC.String(), nil
+	}
+
+	return zoneName(l.c.tz)
+}
+
+// implements sql.Scanner, loading the timezone by its name (e.g. "Europe/Madrid" or "UTC+01:00") and keeping the expression
+func (l *LocationColumn) Scan(src any) error {
+	name, err := scanText(src)
+	if err != nil {
+		return err
+	}
+
+	tz, ok := fixedZone(name)
+	if !ok {
+		if tz, err = time.LoadLocation(name); err != nil {
+			return err
+		}
+	}
+
+	*l.c = *l.c.In(tz)
+
+	return nil
+}
+
+// returns the text of a text column, or ErrUnsupportedScanType for NULL and other types
+func scanText(src any) (string, error) {
+	switch v := src.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	default:
+		return "", ErrUnsupportedScanType
+	}
+}
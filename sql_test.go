@@ -0,0 +1,125 @@
+//go:build !cron_nosql
+
+package cron
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestScanValue(t *testing.T) {
+	var c Cron
+	if err := c.Scan([]byte("0 12 * * 1-5")); err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := c.Value()
+	if err != nil || v != "CRON_TZ=UTC 0 12 * * 1-5" {
+		t.Fatalf("unexpected value %v, %v", v, err)
+	}
+
+	// the timezone is stored with the expression
+	if madrid, err := time.LoadLocation("Europe/Madrid"); err == nil {
+		if v, err = MustParse("0 12 * * 1-5", madrid).Value(); err != nil {
+			t.Fatal(err)
+		}
+
+		var scanned Cron
+		if err := scanned.Scan(v); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := scanned.Location().String(); got != "Europe/Madrid" {
+			t.Fatalf("expected Europe/Madrid, got %q from %q", got, v)
+		}
+	}
+
+	seconds, _ := NewParser(WithSeconds()).Parse("*/30 * * * * *")
+	if v, err = seconds.Value(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Scan(v); err != nil || c.String() != v {
+		t.Fatalf("expected %q to round trip, got %q, %v", v, &c, err)
+	}
+
+	if err := c.Scan(1); err != ErrUnsupportedScanType {
+		t.Fatalf("expected ErrUnsupportedScanType, got %v", err)
+	}
+
+	if err := c.Scan(nil); err != ErrUnsupportedScanType {
+		t.Fatalf("expected ErrUnsupportedScanType for NULL, got %v", err)
+	}
+}
+
+func TestNullCron(t *testing.T) {
+	n := NullCron{Valid: true}
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Fatalf("expected NULL to scan as not valid, got %v, %v", n.Valid, err)
+	}
+
+	if v, err := n.Value(); v != nil || err != nil {
+		t.Fatalf("expected a NULL value, got %v, %v", v, err)
+	}
+
+	if err := n.Scan("CRON_TZ=UTC 0 12 * * 1-5"); err != nil || !n.Valid {
+		t.Fatalf("expected a valid schedule, got %v, %v", n.Valid, err)
+	}
+
+	if v, err := n.Value(); v != "CRON_TZ=UTC 0 12 * * 1-5" || err != nil {
+		t.Fatalf("unexpected value %v, %v", v, err)
+	}
+
+	if err := n.Scan(1); err != ErrUnsupportedScanType || n.Valid {
+		t.Fatalf("expected ErrUnsupportedScanType, got %v, %v", n.Valid, err)
+	}
+}
+
+func TestColumns(t *testing.T) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	expr, tz := MustParse("0 12 * * 1-5", madrid).Columns()
+
+	exprValue, err := expr.Value()
+	if err != nil || exprValue != "0 12 * * 1-5" {
+		t.Fatalf("unexpected expression value %v, %v", exprValue, err)
+	}
+
+	tzValue, err := tz.Value()
+	if err != nil || tzValue != "Europe/Madrid" {
+		t.Fatalf("unexpected timezone value %v, %v", tzValue, err)
+	}
+
+	// the columns are scanned in any order
+	for _, locationFirst := range []bool{false, true} {
+		var c Cron
+		expr, tz := c.Columns()
+
+		if locationFirst {
+			err = errors.Join(tz.Scan([]byte(tzValue.(string))), expr.Scan(exprValue))
+		} else {
+			err = errors.Join(expr.Scan(exprValue), tz.Scan([]byte(tzValue.(string))))
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if c.Location().String() != "Europe/Madrid" || c.String() != "0 12 * * 1-5" {
+			t.Errorf("expected 0 12 * * 1-5 in Europe/Madrid, got %q in %v", &c, c.Location())
+		}
+	}
+
+	var c Cron
+	if _, tz := c.Columns(); tz.Scan("Nowhere/Nowhere") == nil {
+		t.Error("expected an error for an unknown timezone")
+	}
+
+	if v, err := (&LocationColumn{&Cron{}}).Value(); v != "UTC" || err != nil {
+		t.Errorf("expected UTC for a schedule without timezone, got %v, %v", v, err)
+	}
+}
@@ -0,0 +1,216 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// the shortcuts of the systemd calendar events, in their normalized form
+	systemdShortcuts = map[string]string{
+		"minutely":     "*-*-* *:*:00",
+		"hourly":       "*-*-* *:00:00",
+		"daily":        "*-*-* 00:00:00",
+		"weekly":       "Mon *-*-* 00:00:00",
+		"monthly":      "*-*-01 00:00:00",
+		"quarterly":    "*-01,04,07,10-01 00:00:00",
+		"semiannually": "*-01,07-01 00:00:00",
+		"yearly":       "*-01-01 00:00:00",
+		"annually":     "*-01-01 00:00:00",
+	}
+
+	// the weekday names of systemd, full or abbreviated, indexed from sunday
+	systemdWeekdays = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+)
+
+// parses a systemd calendar event (the OnCalendar= setting of timers) and returns a new schedule representing the given spec
+//
+// the event is "[weekdays] [year-month-day] [hour:minute[:second]] [timezone]"; e.g., "Mon..Fri *-*-* 06:00:00", "*-*-01 04:30" or "Sat 09:00 Europe/Madrid". The values are "*", numbers, lists ("1,15"), ranges ("1..5") and repetitions ("0/15"), the day may be counted back from the end of the month with "~" ("*-02~03" is the third last day of february), and the shortcuts (minutely, hourly, daily, weekly, monthly, quarterly, semiannually, yearly, annually) are accepted. The date defaults to every day and the time to 00:00:00. A trailing timezone overrides tz
+//
+// it returns an error when the syntax of expression is wrong
+func ParseSystemd(expr string, tz *time.Location) (*Cron, error) {
+	if err := checkLength(expr); err != nil {
+		return nil, err
+	}
+
+	expr = strings.TrimSpace(expr)
+
+	if normalized, ok := systemdShortcuts[strings.ToLower(expr)]; ok {
+		expr = normalized
+	}
+
+	tokens := strings.Fields(expr)
+	if len(tokens) == 0 {
+		return nil, &ParseError{Token: expr, Reason: "the calendar event is empty"}
+	}
+
+	// second, minute, hour, day of month, month, day of week and year
+	fields := []string{"0", "0", "0", "*", "*", "*", "*"}
+
+	i := 0
+
+	if dow, ok := systemdWeekdayField(tokens[i]); ok {
+		fields[5] = dow
+		i++
+	}
+
+	if i < len(tokens) && !strings.Contains(tokens[i], ":") && strings.ContainsAny(tokens[i], "-~") {
+		if err := systemdDate(tokens[i], fields); err != nil {
+			return nil, err
+		}
+
+		i++
+	}
+
+	if i < len(tokens) && strings.Contains(tokens[i], ":") {
+		if err := systemdTime(tokens[i], fields); err != nil {
+			return nil, err
+		}
+
+		i++
+	}
+
+	if i == len(tokens)-1 {
+		loc, err := SystemZones.LoadLocation(tokens[i])
+		if err != nil {
+			return nil, &ParseError{
+				Field:  "timezone",
+				Token:  tokens[i],
+				Reason: fmt.Sprintf("unknown timezone '%s'", tokens[i]),
+			}
+		}
+
+		tz = loc
+		i++
+	}
+
+	if i < len(tokens) {
+		return nil, &ParseError{
+			Token:      strings.Join(tokens[i:], " "),
+			Reason:     fmt.Sprintf("unexpected '%s'", strings.Join(tokens[i:], " ")),
+			Suggestion: "use weekdays, year-month-day, hour:minute:second and a timezone, in this order",
+		}
+	}
+
+	return parseFields(fields, boundDOWInput, tz)
+}
+
+// returns the same result as ParseSystemd, but it panics when the syntax of expression is wrong
+func MustParseSystemd(expr string, tz *time.Location) *Cron {
+	c, err := ParseSystemd(expr, tz)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+// returns the day of week field of a list of systemd weekdays and ranges ("Mon..Fri,Sun"), or false if the token is not made of weekday names
+func systemdWeekdayField(token string) (string, bool) {
+	var parts []string
+
+	for _, item := range strings.Split(token, ",") {
+		var days []string
+
+		for _, name := range strings.Split(item, "..") {
+			weekday := systemdWeekday(name)
+			if weekday < 0 {
+				return "", false
+			}
+
+			days = append(days, strconv.Itoa(weekday))
+		}
+
+		if len(days) > 2 {
+			return "", false
+		}
+
+		parts = append(parts, strings.Join(days, "-"))
+	}
+
+	return strings.Join(parts, ","), true
+}
+
+// returns the weekday (0-6) of a full or abbreviated systemd weekday name, or -1 if it is not one
+func systemdWeekday(name string) int {
+	name = strings.ToLower(name)
+
+	for i, weekday := range systemdWeekdays {
+		if len(name) >= 3 && strings.HasPrefix(weekday, name) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// sets the year, month and day of month fields of a systemd date ("year-month-day" or "month-day", with "~" before a day counted back from the end of the month)
+func systemdDate(token string, fields []string) error {
+	head, day, fromEnd := strings.Cut(token, "~")
+	if !fromEnd {
+		i := strings.LastIndex(token, "-")
+		head, day = token[:i], token[i+1:]
+	}
+
+	year, month, hasYear := strings.Cut(head, "-")
+	if !hasYear {
+		year, month = "*", head
+	}
+
+	if year == "" || month == "" || day == "" || strings.ContainsAny(month, "-~") {
+		return &ParseError{
+			Token:      token,
+			Reason:     fmt.Sprintf("'%s' is not a valid date", token),
+			Suggestion: "use year-month-day or month-day",
+		}
+	}
+
+	if fromEnd {
+		var days []string
+		for _, item := range strings.Split(day, ",") {
+			n, err := strconv.Atoi(item)
+			if err != nil || n < 1 || n > boundDOM.max {
+				return boundDOM.syntaxError(token, fmt.Sprintf("'~%s' is not a day counted back from the end of the month", item), "use a list of numbers from 1 (the last day) to 31")
+			}
+
+			days = append(days, "L-"+strconv.Itoa(n-1))
+		}
+
+		day = strings.Join(days, ",")
+	}
+
+	fields[6], fields[4], fields[3] = systemdValues(year), systemdValues(month), systemdValues(day)
+
+	return nil
+}
+
+// sets the hour, minute and second fields of a systemd time ("hour:minute" or "hour:minute:second")
+func systemdTime(token string, fields []string) error {
+	parts := strings.Split(token, ":")
+	if len(parts) != 2 && len(parts) != 3 {
+		return &ParseError{
+			Token:      token,
+			Reason:     fmt.Sprintf("'%s' is not a valid time", token),
+			Suggestion: "use hour:minute or hour:minute:second",
+		}
+	}
+
+	if len(parts) == 3 {
+		if strings.Contains(parts[2], ".") {
+			return boundSecond.syntaxError(token, fmt.Sprintf("'%s' has a fraction of second", parts[2]), "use whole seconds")
+		}
+
+		fields[0] = systemdValues(parts[2])
+	}
+
+	fields[2], fields[1] = systemdValues(parts[0]), systemdValues(parts[1])
+
+	return nil
+}
+
+// returns the field expression of a systemd value, whose ranges are written with ".."
+func systemdValues(value string) string {
+	return strings.ReplaceAll(value, "..", "-")
+}
@@ -0,0 +1,82 @@
+package cron
+
+import (
+	"errors"
+	"time"
+)
+
+type (
+	TaskTriggerType int
+
+	// the subset of a Windows Task Scheduler calendar trigger that can be represented as a cron expression
+	TaskTrigger struct {
+		Type TaskTriggerType
+		// the time of day of the occurrences is taken from the start boundary
+		StartBoundary time.Time
+		// DaysInterval and WeeksInterval only support 0 or 1 (every day / every week)
+		DaysInterval  int
+		WeeksInterval int
+		DaysOfWeek    []time.Weekday
+		DaysOfMonth   []int
+		// empty means every month
+		Months []time.Month
+	}
+)
+
+const (
+	TaskDaily TaskTriggerType = iota
+	TaskWeekly
+	TaskMonthly
+)
+
+var (
+	ErrUnsupportedTrigger = errors.New("the task trigger can not be represented as a cron expression")
+)
+
+// converts a Windows Task Scheduler trigger into a schedule in the given timezone
+//
+// the start boundary only provides the time of day; occurrences before it are not filtered, so callers should pass the later of now and the start boundary to Next
+//
+// it returns ErrUnsupportedTrigger for intervals greater than 1 or start boundaries with seconds, and ErrOutOfRange for invalid days or months
+func FromTaskTrigger(trigger TaskTrigger, tz *time.Location) (*Cron, error) {
+	if trigger.StartBoundary.Second() != 0 || trigger.StartBoundary.Nanosecond() != 0 {
+		return nil, ErrUnsupportedTrigger
+	}
+
+	minute, _ := Minute(trigger.StartBoundary.Minute())
+	hour, _ := Hour(trigger.StartBoundary.Hour())
+
+	b := NewBuilder(tz).Minutes(minute).Hours(hour)
+
+	switch trigger.Type {
+	case TaskDaily:
+		if trigger.DaysInterval > 1 {
+			return nil, ErrUnsupportedTrigger
+		}
+	case TaskWeekly:
+		if trigger.WeeksInterval > 1 || len(trigger.DaysOfWeek) == 0 {
+			return nil, ErrUnsupportedTrigger
+		}
+
+		b.Weekdays(trigger.DaysOfWeek...)
+	case TaskMonthly:
+		if len(trigger.DaysOfMonth) == 0 {
+			return nil, ErrUnsupportedTrigger
+		}
+
+		for _, v := range trigger.DaysOfMonth {
+			day, err := Day(v)
+			if err != nil {
+				return nil, err
+			}
+
+			b.Days(day)
+		}
+
+		b.Months(trigger.Months...)
+	default:
+		return nil, ErrUnsupportedTrigger
+	}
+
+	return b.Build()
+}
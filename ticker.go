@@ -0,0 +1,110 @@
+package cron
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type (
+	// an occurrence delivered by TickerWithMeta, with the metadata computed for it
+	Activation[T any] struct {
+		Time time.Time
+		Meta T
+	}
+
+	// stops the scheduled function returned by AfterFunc
+	Handle struct {
+		cancel context.CancelFunc
+		once   sync.Once
+	}
+)
+
+// returns a channel that delivers the time of each occurrence of the schedule as it arrives
+//
+// like time.Ticker, the channel has a buffer of one and occurrences are dropped for slow receivers. The channel is closed when the context is done or when there are no more occurrences within the year limit
+func (s *Cron) Ticker(ctx context.Context) <-chan time.Time {
+	c := make(chan time.Time, 1)
+
+	go func() {
+		defer close(c)
+
+		t := time.Now()
+		for {
+			next, err := s.Next(t)
+			if err != nil {
+				return
+			}
+
+			timer := time.NewTimer(time.Until(next))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+
+			select {
+			case c <- next:
+			default:
+			}
+
+			t = next
+		}
+	}()
+
+	return c
+}
+
+// returns the same channel as Ticker, but each occurrence is delivered with the metadata returned by meta for it (e.g. the data partition or the billing period it covers)
+//
+// meta is called with the time of the occurrence, not the current time, so it is not affected by the delay of the delivery
+func TickerWithMeta[T any](ctx context.Context, s *Cron, meta func(time.Time) T) <-chan Activation[T] {
+	c := make(chan Activation[T], 1)
+
+	go func() {
+		defer close(c)
+
+		for t := range s.Ticker(ctx) {
+			select {
+			case c <- Activation[T]{Time: t, Meta: meta(t)}:
+			default:
+			}
+		}
+	}()
+
+	return c
+}
+
+// parses the expression and calls f in its own goroutine on each occurrence, like time.AfterFunc
+//
+// it returns an error when the syntax of expression is wrong
+func AfterFunc(expr string, tz *time.Location, f func(time.Time)) (*Handle, error) {
+	s, err := Parse(expr, tz)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		for t := range s.Ticker(ctx) {
+			go f(t)
+		}
+	}()
+
+	return &Handle{cancel: cancel}, nil
+}
+
+// prevents any further calls to the function. It returns false if the handle was already stopped
+//
+// calls already in progress are not interrupted
+func (h *Handle) Stop() bool {
+	stopped := false
+	h.once.Do(func() {
+		h.cancel()
+		stopped = true
+	})
+
+	return stopped
+}
@@ -0,0 +1,125 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// resolves the timezone names of the "CRON_TZ=" and "TZ=" prefixes into locations; e.g., to cache them, restrict them or replace them in tests
+	ZoneProvider interface {
+		// returns the location with the given name, or an error if there is none
+		LoadLocation(name string) (*time.Location, error)
+	}
+
+	// adapts a function to the ZoneProvider interface
+	ZoneProviderFunc func(name string) (*time.Location, error)
+)
+
+var (
+	// the provider used by default, which loads the locations with time.LoadLocation
+	SystemZones ZoneProvider = ZoneProviderFunc(time.LoadLocation)
+
+	ErrUnsupportedZone = errors.New("the timezone can not be represented in an expression")
+)
+
+// calls f(name)
+func (f ZoneProviderFunc) LoadLocation(name string) (*time.Location, error) {
+	return f(name)
+}
+
+// returns a fixed zone for a name made of "UTC" or "GMT" and an offset, like "UTC+05:30", "UTC-3" or "GMT+0100", or false if the name is not an offset
+//
+// the zone is named "UTC" followed by the offset as "+hh:mm", so its name is parsed back into the same zone
+func fixedZone(name string) (*time.Location, bool) {
+	if len(name) < 5 || (!strings.HasPrefix(name, "UTC") && !strings.HasPrefix(name, "GMT")) {
+		return nil, false
+	}
+
+	sign := 1
+	switch name[3] {
+	case '+':
+	case '-':
+		sign = -1
+	default:
+		return nil, false
+	}
+
+	offset := strings.Replace(name[4:], ":", "", 1)
+	if strings.Trim(offset, "0123456789") != "" {
+		return nil, false
+	}
+
+	hh, mm := offset, "0"
+	if len(offset) > 2 {
+		hh, mm = offset[:len(offset)-2], offset[len(offset)-2:]
+	}
+
+	hours, err := strconv.Atoi(hh)
+	if err != nil || len(hh) > 2 || hours > 14 {
+		return nil, false
+	}
+
+	minutes, err := strconv.Atoi(mm)
+	if err != nil || minutes > 59 {
+		return nil, false
+	}
+
+	return time.FixedZone(fmt.Sprintf("UTC%c%02d:%02d", name[3], hours, minutes), sign*(hours*60+minutes)*60), true
+}
+
+// returns the name of the timezone for a "CRON_TZ=" prefix, so it is parsed back into the same zone
+//
+// the zones without changes of offset are named by their offset (e.g. "UTC+01:00"), since the name given to time.FixedZone may not be a timezone at all. It returns ErrUnsupportedZone for offsets that fixedZone can't parse back
+func zoneName(tz *time.Location) (string, error) {
+	name := tz.String()
+	if _, ok := fixedZone(name); ok || tz == time.UTC || tz == time.Local {
+		return name, nil
+	}
+
+	// the zones with changes of offset have bounds at any time
+	t := time.Unix(0, 0).In(tz)
+	if start, end := t.ZoneBounds(); !start.IsZero() || !end.IsZero() {
+		return name, nil
+	}
+
+	_, offset := t.Zone()
+	if offset == 0 {
+		return "UTC", nil
+	}
+
+	sign := '+'
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+
+	if offset%60 != 0 || offset/3600 > 14 {
+		return "", ErrUnsupportedZone
+	}
+
+	return fmt.Sprintf("UTC%c%02d:%02d", sign, offset/3600, offset%3600/60), nil
+}
+
+// returns a copy of the schedule with its timezone loaded again by name from zones (SystemZones if nil), so a long running process honors the rules of an updated tz database without a restart
+//
+// fixed offsets, UTC and Local are kept as they are. It returns an error when the timezone can't be loaded again
+func (s *Cron) Refresh(zones ZoneProvider) (*Cron, error) {
+	if zones == nil {
+		zones = SystemZones
+	}
+
+	name := s.tz.String()
+	if _, ok := fixedZone(name); ok || s.tz == time.UTC || s.tz == time.Local {
+		return s.In(s.tz), nil
+	}
+
+	tz, err := zones.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.In(tz), nil
+}